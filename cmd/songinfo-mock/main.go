@@ -0,0 +1,29 @@
+// Имитация внешнего сервиса сведений о песнях для локальной разработки:
+//
+//	go run cmd/songinfo-mock/main.go -addr :8081
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/cutlery47/music-storage/internal/songinfo/songinfotest"
+)
+
+func main() {
+	addr := flag.String("addr", ":8081", "address to listen on")
+	flag.Parse()
+
+	mock := songinfotest.NewMock()
+	mock.Add(
+		models.Song{GroupName: "Muse", SongName: "Supermassive Black Hole"},
+		"16.07.2006",
+		"Ooh baby, don't you know I suffer?\nOoh baby, can you hear me moan?\nYou caught me under false pretenses\nHow long before you let me go?\n\nOoh\nYou set my soul alight\nOoh\nYou set my soul alight",
+		"https://www.youtube.com/watch?v=Xsp3_a-PMTw",
+	)
+
+	log.Printf("song info mock is listening on %v", *addr)
+	log.Fatal(http.ListenAndServe(*addr, mock))
+}
@@ -18,38 +18,20 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/songs": {
+        "/api/v1/albums": {
             "get": {
-                "description": "Get songs by specified filters",
+                "description": "Get a page of albums ordered by group and release date",
                 "tags": [
-                    "Songs"
+                    "Albums"
                 ],
-                "summary": "Get Songs",
+                "summary": "Get Albums",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "desired group",
+                        "description": "group id",
                         "name": "group",
                         "in": "query"
                     },
-                    {
-                        "type": "string",
-                        "description": "desired song",
-                        "name": "song",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "upper time-bound for when the song was released",
-                        "name": "releasedBefore",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "lower time-bound for when the song was released",
-                        "name": "releasedAfter",
-                        "in": "query"
-                    },
                     {
                         "type": "integer",
                         "description": "pagination limit",
@@ -71,7 +53,7 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/models.SongWithDetail"
+                                "$ref": "#/definitions/models.Album"
                             }
                         }
                     },
@@ -81,12 +63,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/echo.HTTPError"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/echo.HTTPError"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -95,60 +71,62 @@ const docTemplate = `{
                     }
                 }
             },
-            "put": {
-                "description": "Upload specific song data",
+            "post": {
+                "description": "Create a new album. The group is created if it doesn't exist yet",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
                 "tags": [
-                    "Songs"
+                    "Albums"
                 ],
-                "summary": "Update Song",
+                "summary": "Create Album",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "initial group",
-                        "name": "group",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "initial song",
-                        "name": "song",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "edited group",
-                        "name": "group",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "edited song",
-                        "name": "song",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "edited release date",
-                        "name": "releaseDate",
-                        "in": "formData",
-                        "required": true
+                        "description": "album data",
+                        "name": "album",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.AlbumRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Album"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "edited link to some media",
-                        "name": "link",
-                        "in": "formData",
-                        "required": true
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
                     },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/albums/{id}": {
+            "get": {
+                "description": "Get info about a particular album",
+                "tags": [
+                    "Albums"
+                ],
+                "summary": "Get Album",
+                "parameters": [
                     {
                         "type": "string",
-                        "description": "edited song lyrics",
-                        "name": "text",
-                        "in": "formData",
+                        "description": "album id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -156,7 +134,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/models.AlbumWithDetail"
                         }
                     },
                     "400": {
@@ -179,47 +157,32 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
-                "description": "Upload a new song",
+            "put": {
+                "description": "Replace album data",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
                 "tags": [
-                    "Songs"
+                    "Albums"
                 ],
-                "summary": "Upload Song",
+                "summary": "Update Album",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "desired group",
-                        "name": "group",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "desired song",
-                        "name": "song",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "song release date",
-                        "name": "releaseDate",
-                        "in": "formData",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "link to some media",
-                        "name": "link",
-                        "in": "formData",
+                        "description": "album id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "song lyrics",
-                        "name": "text",
-                        "in": "formData",
-                        "required": true
+                        "description": "edited album data",
+                        "name": "album",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.AlbumRequest"
+                        }
                     }
                 ],
                 "responses": {
@@ -250,24 +213,17 @@ const docTemplate = `{
                 }
             },
             "delete": {
-                "description": "Delete specific song",
+                "description": "Delete an album. The album's songs are kept",
                 "tags": [
-                    "Songs"
+                    "Albums"
                 ],
-                "summary": "Delete Song",
+                "summary": "Delete Album",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "desired group",
-                        "name": "group",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "desired song",
-                        "name": "song",
-                        "in": "query",
+                        "description": "album id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -299,26 +255,19 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/songs/info": {
+        "/api/v1/albums/{id}/tracks": {
             "get": {
-                "description": "Get info about a particular song",
+                "description": "Get the tracklist of a particular album ordered by disc and track number",
                 "tags": [
-                    "Songs"
+                    "Albums"
                 ],
-                "summary": "Get Info",
+                "summary": "Get Album Tracks",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "desired group",
-                        "name": "group",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "desired song",
-                        "name": "song",
-                        "in": "query",
+                        "description": "album id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -326,7 +275,10 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.SongDetail"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Track"
+                            }
                         }
                     },
                     "400": {
@@ -350,40 +302,88 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/songs/text": {
-            "get": {
-                "description": "Get specified songs' lyrics",
+        "/api/v1/albums/{id}/tracks/{songId}": {
+            "put": {
+                "description": "Add a song to the album at the given position, or move it if it's already on the album",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
                 "tags": [
-                    "Songs"
+                    "Albums"
                 ],
-                "summary": "Get Texts",
+                "summary": "Set Album Track",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "desired group",
-                        "name": "group",
-                        "in": "query",
+                        "description": "album id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "desired song",
-                        "name": "song",
-                        "in": "query",
+                        "description": "song id",
+                        "name": "songId",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "pagination limit",
-                        "name": "limit",
-                        "in": "query",
+                        "description": "track position",
+                        "name": "track",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.TrackRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a song from the album. The song itself is kept",
+                "tags": [
+                    "Albums"
+                ],
+                "summary": "Remove Album Track",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "album id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "description": "pagination offset",
-                        "name": "offset",
-                        "in": "query",
+                        "type": "string",
+                        "description": "song id",
+                        "name": "songId",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -414,40 +414,4053 @@ const docTemplate = `{
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "echo.HTTPError": {
-            "type": "object",
-            "properties": {
-                "message": {}
-            }
         },
-        "models.SongDetail": {
-            "type": "object",
-            "properties": {
-                "link": {
-                    "type": "string"
-                },
-                "releaseDate": {
-                    "type": "string"
+        "/api/v1/genres": {
+            "get": {
+                "description": "Get all genres ordered by name, along with their parent genres",
+                "tags": [
+                    "Genres"
+                ],
+                "summary": "Get Genres",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Genre"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
                 }
-            }
-        },
-        "models.SongWithDetail": {
-            "type": "object",
-            "properties": {
-                "groupName": {
-                    "type": "string"
-                },
-                "link": {
+            },
+            "post": {
+                "description": "Create a new genre, optionally as a subgenre of an existing one. Names are stored in lowercase",
+                "tags": [
+                    "Genres"
+                ],
+                "summary": "Create Genre",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "genre name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "parent genre name",
+                        "name": "parent",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Genre"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/genres/{id}": {
+            "delete": {
+                "description": "Delete a genre. Its subgenres become top-level genres",
+                "tags": [
+                    "Genres"
+                ],
+                "summary": "Delete Genre",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "genre id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/groups": {
+            "get": {
+                "description": "Get a page of groups ordered by name",
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Get Groups",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "pagination limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Group"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new group",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Create Group",
+                "parameters": [
+                    {
+                        "description": "group data",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.GroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Group"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/groups/{id}": {
+            "get": {
+                "description": "Get info about a particular group",
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Get Group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GroupWithDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Rename a group. All of the group's songs are renamed at once",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Rename Group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new group data",
+                        "name": "group",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.GroupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Delete a group. A group that still has songs (including songs in the trash and songs featuring the group) can't be deleted: delete the songs first and wait for the trash to be purged",
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Delete Group",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/groups/{id}/songs": {
+            "get": {
+                "description": "Get songs of a particular group",
+                "tags": [
+                    "Groups"
+                ],
+                "summary": "Get Group Songs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "group id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SongWithDetail"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/links/broken": {
+            "get": {
+                "description": "Get song links that failed their last background check (error response or no response at all), most recently checked first",
+                "tags": [
+                    "Links"
+                ],
+                "summary": "Get Broken Links",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "pagination limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.BrokenLink"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/people": {
+            "get": {
+                "description": "Get a page of songwriters and producers ordered by name",
+                "tags": [
+                    "People"
+                ],
+                "summary": "Get People",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "pagination limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.Person"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new songwriter or producer",
+                "tags": [
+                    "People"
+                ],
+                "summary": "Create Person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "full name",
+                        "name": "name",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Person"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/people/{id}": {
+            "get": {
+                "description": "Get info about a particular songwriter or producer",
+                "tags": [
+                    "People"
+                ],
+                "summary": "Get Person",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "person id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.Person"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/people/{id}/songs": {
+            "get": {
+                "description": "Get songs a particular person is credited on, along with their roles",
+                "tags": [
+                    "People"
+                ],
+                "summary": "Get Person Songs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "person id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.PersonSong"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs": {
+            "get": {
+                "description": "Get songs by specified filters. Responds with a page of songs with pagination metadata; links to neighbouring pages are also sent in the Link header. If the cursor parameter is present (empty for the first page), keyset pagination is used: next/prev cursors are returned instead of offsets and offset is ignored",
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Get Songs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "desired group",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "desired song",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "any credited artist: primary, featured or remixer",
+                        "name": "artist",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "upper time-bound for when the song was released",
+                        "name": "releasedBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "lower time-bound for when the song was released",
+                        "name": "releasedAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "how group, song and artist are matched: exact (default), icase, prefix or fuzzy (trigram similarity, ordered by similarity)",
+                        "name": "match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma-separated tags the song should have",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma-separated tags the song should not have",
+                        "name": "excludeTags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "how tags are matched: all (default) or any",
+                        "name": "tagMatch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma-separated genres the song should belong to, subgenres included",
+                        "name": "genres",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma-separated genres the song should not belong to, subgenres included",
+                        "name": "excludeGenres",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "how genres are matched: all (default) or any",
+                        "name": "genreMatch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "lower bound for song duration in seconds",
+                        "name": "minDuration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "upper bound for song duration in seconds",
+                        "name": "maxDuration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "lower bound for song tempo",
+                        "name": "minBpm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "upper bound for song tempo",
+                        "name": "maxBpm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "lyrics language, ISO 639-1 code",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "whether the song has explicit lyrics",
+                        "name": "explicit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "musical key, e.g. C, F#, Bbm or A minor",
+                        "name": "key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISRC code, hyphens are optional",
+                        "name": "isrc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma-separated sort keys: group, song, releaseDate, createdAt; prefix with - for descending order, e.g. -releaseDate,song. Defaults to group,song",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination offset (required without cursor)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pagination cursor from a previous page; empty for the first page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/common.PageMeta"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.SongWithDetail"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Upload specific song data. The body may be sent either as application/json or as form data with the same fields. releaseDate is optional: if omitted, the release date of the earliest album containing the song is used",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Update Song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "initial group",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "initial song",
+                        "name": "song",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "edited song data",
+                        "name": "upd",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.SongRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Upload a new song. The body may be sent either as application/json or as form data with the same fields. releaseDate is optional: if omitted, the release date of the earliest album containing the song is used. Only group and song are required when the song info service is configured: missing text, link and releaseDate are fetched from it",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Upload Song",
+                "parameters": [
+                    {
+                        "description": "song data",
+                        "name": "song",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.SongRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Move specific song to the trash. It can be restored via /api/v2/trash until it is permanently removed after the retention period",
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Delete Song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "desired group",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "desired song",
+                        "name": "song",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update specific song data. Only provided fields are changed; lyrics are rewritten only if text is provided",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Patch Song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "initial group",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "initial song",
+                        "name": "song",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "fields to update",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.SongPatchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/credits": {
+            "post": {
+                "description": "Credit a person on a specific song as a lyricist, composer or producer. The person is created if they don't exist yet",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Add Credit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "desired group",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "desired song",
+                        "name": "song",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "person and role",
+                        "name": "credit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.CreditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongCredit"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a person's credit from a specific song, either in the given role or in all roles",
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Remove Credit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "desired group",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "desired song",
+                        "name": "song",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "person id",
+                        "name": "person",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "lyricist, composer or producer; all roles if omitted",
+                        "name": "role",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/info": {
+            "get": {
+                "description": "Get info about a particular song, including its songwriters and producers",
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Get Info",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "desired group",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "desired song",
+                        "name": "song",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongDetailWithCredits"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/links": {
+            "get": {
+                "description": "Get links of a specific song: the primary link (also returned as the song's link) goes first",
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Get Links",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "desired group",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "desired song",
+                        "name": "song",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SongLink"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a link to a specific song. A song has a single primary link, so adding a primary link replaces the current one",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Add Link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "desired group",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "desired song",
+                        "name": "song",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "description": "link data",
+                        "name": "link",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.LinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongLink"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Remove a link from a specific song. The primary link can't be removed, only replaced",
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Remove Link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "desired group",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "desired song",
+                        "name": "song",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "link id",
+                        "name": "link",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/search": {
+            "get": {
+                "description": "Full-text search over lyrics. Returns a page of songs ranked by relevance together with the total number of matching songs, each with its best matching verse highlighted with \u003cmark\u003e tags",
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Search Songs",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "search query (supports quotes, OR and -exclusion)",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "text search language: english (en), russian (ru) or simple. Defaults to the configured language",
+                        "name": "lang",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/common.PageMeta"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.SearchResult"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/songs/text": {
+            "get": {
+                "description": "Get specified songs' lyrics page by verses with pagination metadata; links to neighbouring pages are also sent in the Link header. Responds with plain text if text/plain is preferred in the Accept header",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "Songs"
+                ],
+                "summary": "Get Texts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "desired group",
+                        "name": "group",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "desired song",
+                        "name": "song",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination offset (required without cursor)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pagination cursor from a previous page; empty for the first page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "return only verses of this section type (intro, verse, pre-chorus, chorus, bridge, hook, outro, other)",
+                        "name": "section",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "skip repeated verses (e.g. repeated choruses)",
+                        "name": "collapse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "prefix verses with section labels, e.g. [Chorus] (text/plain only)",
+                        "name": "labels",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/common.PageMeta"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Verse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/tags": {
+            "get": {
+                "description": "Get the most used tags among songs matching the specified filters, along with song counts",
+                "tags": [
+                    "Tags"
+                ],
+                "summary": "Get Tag Counts",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "desired group",
+                        "name": "group",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "desired song",
+                        "name": "song",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "any credited artist: primary, featured or remixer",
+                        "name": "artist",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "upper time-bound for when the song was released",
+                        "name": "releasedBefore",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "lower time-bound for when the song was released",
+                        "name": "releasedAfter",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "how group, song and artist are matched: exact (default), icase, prefix or fuzzy",
+                        "name": "match",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma-separated tags the song should have",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma-separated tags the song should not have",
+                        "name": "excludeTags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "how tags are matched: all (default) or any",
+                        "name": "tagMatch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma-separated genres the song should belong to, subgenres included",
+                        "name": "genres",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comma-separated genres the song should not belong to, subgenres included",
+                        "name": "excludeGenres",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "how genres are matched: all (default) or any",
+                        "name": "genreMatch",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "lower bound for song duration in seconds",
+                        "name": "minDuration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "upper bound for song duration in seconds",
+                        "name": "maxDuration",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "lower bound for song tempo",
+                        "name": "minBpm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "upper bound for song tempo",
+                        "name": "maxBpm",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "lyrics language, ISO 639-1 code",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "whether the song has explicit lyrics",
+                        "name": "explicit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "musical key, e.g. C, F#, Bbm or A minor",
+                        "name": "key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ISRC code, hyphens are optional",
+                        "name": "isrc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "maximum number of tags",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.TagCount"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/audit": {
+            "get": {
+                "description": "Get the audit log of changes to songs, albums, groups, people and genres, newest first. Each entry holds the author (X-Author header; not authenticated, accepted only from trusted proxies when HTTP_TRUSTED_PROXIES is set), action, entity type and id, entity state before and after the change, request id (X-Request-Id header) and client IP. Purging a song from the trash is logged with the purge action and no author",
+                "tags": [
+                    "Audit"
+                ],
+                "summary": "Get Audit Log",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "pagination limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "author of the change",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "action (create, update, delete, restore, purge)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity type (song, album, group, person, genre)",
+                        "name": "entityType",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "entity id",
+                        "name": "entityId",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "changes made at or after this time (RFC 3339 or YYYY-MM-DD)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "changes made before this time (RFC 3339 or YYYY-MM-DD)",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/common.PageMeta"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.AuditEntry"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/songs": {
+            "post": {
+                "description": "Upload a new song and get its id. The body may be sent either as application/json or as form data with the same fields. releaseDate is optional: if omitted, the release date of the earliest album containing the song is used. Only group and song are required when the song info service is configured: missing text, link and releaseDate are fetched from it",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Upload Song",
+                "parameters": [
+                    {
+                        "description": "song data",
+                        "name": "song",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.SongRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "author of the change, saved in the revision",
+                        "name": "X-Author",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.createdResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/songs/{id}": {
+            "get": {
+                "description": "Get a song with its details by id",
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Get Song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongWithDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace song data by id. The body may be sent either as application/json or as form data with the same fields. releaseDate is optional: if omitted, the release date of the earliest album containing the song is used",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Update Song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "edited song data",
+                        "name": "upd",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.SongRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "author of the change, saved in the revision",
+                        "name": "X-Author",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Move a song to the trash by id. It can be restored via /api/v2/trash/{id}/restore until it is permanently removed after the retention period",
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Delete Song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Partially update song data by id. Only provided fields are changed; lyrics are rewritten only if text is provided",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Patch Song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "fields to update",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.SongPatchRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "author of the change, saved in the revision",
+                        "name": "X-Author",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/songs/{id}/credits": {
+            "post": {
+                "description": "Credit a person on a song as a lyricist, composer or producer. The person is created if they don't exist yet",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Add Credit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "person and role",
+                        "name": "credit",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.CreditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongCredit"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/songs/{id}/credits/{personId}": {
+            "delete": {
+                "description": "Remove a person's credit from a song, either in the given role or in all roles",
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Remove Credit",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "person id",
+                        "name": "personId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "lyricist, composer or producer; all roles if omitted",
+                        "name": "role",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/songs/{id}/genres": {
+            "get": {
+                "description": "Get song's genres in alphabetical order",
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Get Genres",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace song's genres. All genres should already exist; an empty list removes all genres",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Set Genres",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new genres",
+                        "name": "genres",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.GenresRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/songs/{id}/links": {
+            "get": {
+                "description": "Get song's links: the primary link (also returned as the song's link) goes first",
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Get Links",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.SongLink"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Add a link to a song. A song has a single primary link, so adding a primary link replaces the current one",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Add Link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "link data",
+                        "name": "link",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.LinkRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.SongLink"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/songs/{id}/links/{linkId}": {
+            "delete": {
+                "description": "Remove a link from a song. The primary link can't be removed, only replaced",
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Remove Link",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "link id",
+                        "name": "linkId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/songs/{id}/revisions": {
+            "get": {
+                "description": "Get song revisions, newest first. A revision is written on every create, update, patch and restore",
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Get Revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/common.PageMeta"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Revision"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/songs/{id}/revisions/diff": {
+            "get": {
+                "description": "Compare two song revisions: changed fields and lyrics verse by verse. Verses are marked as unchanged, added, removed or changed",
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Diff Revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "older revision number",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "newer revision number",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RevisionDiff"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/songs/{id}/revisions/{rev}": {
+            "get": {
+                "description": "Get a song revision with its details and full lyrics. releaseDate is the one set explicitly, without the album fallback",
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Get Revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "revision number",
+                        "name": "rev",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RevisionWithDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/songs/{id}/revisions/{rev}/restore": {
+            "post": {
+                "description": "Restore song details and lyrics from a revision. The result is written as a new revision, so the history is never rewritten. Credits other than the primary artist, genres, tags and additional links are left as they are",
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Restore Revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "revision number",
+                        "name": "rev",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "author of the change",
+                        "name": "X-Author",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/v2.restoredResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/songs/{id}/tags": {
+            "get": {
+                "description": "Get song's tags in alphabetical order",
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Get Tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replace song's tags. Tags are free-form and stored in lowercase; an empty list removes all tags",
+                "consumes": [
+                    "application/json",
+                    "application/x-www-form-urlencoded"
+                ],
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Set Tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new tags",
+                        "name": "tags",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/common.TagsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/songs/{id}/text": {
+            "get": {
+                "description": "Get song lyrics page by id with pagination metadata; links to neighbouring pages are also sent in the Link header. Responds with plain text if text/plain is preferred in the Accept header",
+                "produces": [
+                    "application/json",
+                    "text/plain"
+                ],
+                "tags": [
+                    "Songs v2"
+                ],
+                "summary": "Get Text",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination offset (required without cursor)",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "pagination cursor from a previous page; empty for the first page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "return only verses of this section type (intro, verse, pre-chorus, chorus, bridge, hook, outro, other)",
+                        "name": "section",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "skip repeated verses (e.g. repeated choruses)",
+                        "name": "collapse",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "prefix verses with section labels, e.g. [Chorus] (text/plain only)",
+                        "name": "labels",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/common.PageMeta"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.Verse"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/trash": {
+            "get": {
+                "description": "Get deleted songs, most recently deleted first. Deleted songs are hidden from all other endpoints and are permanently removed after the configured retention period",
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Get Trash",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "pagination limit",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "pagination offset",
+                        "name": "offset",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/common.PageMeta"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "items": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/models.DeletedSong"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v2/trash/{id}/restore": {
+            "post": {
+                "description": "Restore a deleted song with its lyrics, details, links and credits. Fails if the group already has another song with the same name or the ISRC of the song is taken by another song",
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore Song",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "song id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/echo.HTTPError"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/outbound": {
+            "get": {
+                "description": "Get statistics of outbound requests to external services by service name: request and failure counts, cache hits, circuit breaker and bulkhead rejections, latency (in nanoseconds), circuit breaker state, in-flight requests and cache size",
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Get Outbound Stats",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "$ref": "#/definitions/httpclient.Stats"
+                            }
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "common.AlbumRequest": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string",
+                    "example": "The Beatles"
+                },
+                "releaseDate": {
+                    "type": "string",
+                    "example": "1969-09-26"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Abbey Road"
+                },
+                "type": {
+                    "description": "LP (по умолчанию), EP, single или compilation",
+                    "type": "string",
+                    "example": "LP"
+                }
+            }
+        },
+        "common.CreditRequest": {
+            "type": "object",
+            "properties": {
+                "person": {
+                    "type": "string",
+                    "example": "Paul McCartney"
+                },
+                "role": {
+                    "description": "lyricist, composer или producer",
+                    "type": "string",
+                    "example": "lyricist"
+                }
+            }
+        },
+        "common.GenresRequest": {
+            "type": "object",
+            "properties": {
+                "genres": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "common.GroupRequest": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "string",
+                    "example": "The Beatles"
+                }
+            }
+        },
+        "common.LinkRequest": {
+            "type": "object",
+            "properties": {
+                "region": {
+                    "description": "код страны ISO 3166-1, пустое значение - ссылка доступна везде",
+                    "type": "string",
+                    "example": "GB"
+                },
+                "type": {
+                    "description": "primary, youtube, streaming, lyrics или purchase",
+                    "type": "string",
+                    "example": "youtube"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.youtube.com/watch?v=A_MjCqQoLLA"
+                }
+            }
+        },
+        "common.PageMeta": {
+            "type": "object",
+            "properties": {
+                "has_more": {
+                    "type": "boolean"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "next_offset": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "prev_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "common.SongPatchRequest": {
+            "type": "object",
+            "properties": {
+                "bpm": {
+                    "type": "integer",
+                    "example": 74
+                },
+                "duration": {
+                    "type": "integer",
+                    "example": 431
+                },
+                "explicit": {
+                    "type": "boolean"
+                },
+                "group": {
+                    "type": "string",
+                    "example": "The Beatles"
+                },
+                "isrc": {
+                    "type": "string",
+                    "example": "GB-AYE-68-00011"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "F"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "en"
+                },
+                "link": {
+                    "type": "string",
+                    "example": "https://example.com/hey_jude"
+                },
+                "releaseDate": {
+                    "type": "string",
+                    "example": "1968-08-26"
+                },
+                "song": {
+                    "type": "string",
+                    "example": "Hey Jude"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Hey Jude, dont make it bad"
+                }
+            }
+        },
+        "common.SongRequest": {
+            "type": "object",
+            "properties": {
+                "bpm": {
+                    "description": "темп, 0 - неизвестен",
+                    "type": "integer",
+                    "example": 74
+                },
+                "duration": {
+                    "description": "длительность в секундах, 0 - неизвестна",
+                    "type": "integer",
+                    "example": 431
+                },
+                "explicit": {
+                    "type": "boolean"
+                },
+                "featured": {
+                    "description": "приглашенные исполнители; также выделяются из названия группы вида \"A feat. B\"",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "group": {
+                    "type": "string",
+                    "example": "The Beatles"
+                },
+                "isrc": {
+                    "type": "string",
+                    "example": "GB-AYE-68-00011"
+                },
+                "key": {
+                    "type": "string",
+                    "example": "F"
+                },
+                "language": {
+                    "description": "код языка ISO 639-1",
+                    "type": "string",
+                    "example": "en"
+                },
+                "link": {
+                    "type": "string",
+                    "example": "https://example.com/hey_jude"
+                },
+                "releaseDate": {
+                    "type": "string",
+                    "example": "1968-08-26"
+                },
+                "remixers": {
+                    "description": "авторы ремикса",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "song": {
+                    "type": "string",
+                    "example": "Hey Jude"
+                },
+                "text": {
+                    "type": "string",
+                    "example": "Hey Jude, dont make it bad"
+                }
+            }
+        },
+        "common.TagsRequest": {
+            "type": "object",
+            "properties": {
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "common.TrackRequest": {
+            "type": "object",
+            "properties": {
+                "disc": {
+                    "description": "номер диска, по умолчанию 1",
+                    "type": "integer",
+                    "example": 1
+                },
+                "track": {
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "echo.HTTPError": {
+            "type": "object",
+            "properties": {
+                "message": {}
+            }
+        },
+        "httpclient.Stats": {
+            "type": "object",
+            "properties": {
+                "bulkheadRejections": {
+                    "type": "integer"
+                },
+                "cacheEntries": {
+                    "type": "integer"
+                },
+                "cacheHits": {
+                    "type": "integer"
+                },
+                "cacheMisses": {
+                    "type": "integer"
+                },
+                "circuitRejections": {
+                    "type": "integer"
+                },
+                "failures": {
+                    "description": "сетевые ошибки и ответы 5xx/429",
+                    "type": "integer"
+                },
+                "inFlight": {
+                    "type": "integer"
+                },
+                "maxLatency": {
+                    "type": "integer"
+                },
+                "requests": {
+                    "description": "все вызовы Do, включая ответы из кэша и отклоненные запросы",
+                    "type": "integer"
+                },
+                "state": {
+                    "description": "состояние предохранителя: closed, open, half-open",
+                    "type": "string"
+                },
+                "totalLatency": {
+                    "description": "суммарное время запросов, дошедших до удаленного сервиса",
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Album": {
+            "type": "object",
+            "properties": {
+                "groupName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "releaseDate": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AlbumWithDetail": {
+            "type": "object",
+            "properties": {
+                "groupName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "releaseDate": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "trackCount": {
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Artist": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AuditEntry": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "description": "автор изменения из заголовка X-Author, nil - неизвестен.\nАвтор не аутентифицируется: его указывает клиент или доверенный прокси (HTTP_TRUSTED_PROXIES)",
+                    "type": "string"
+                },
+                "after": {
+                    "type": "object"
+                },
+                "before": {
+                    "description": "состояние сущности до и после изменения, null - сущности не было",
+                    "type": "object"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "entityID": {
+                    "type": "string"
+                },
+                "entityType": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "requestID": {
+                    "description": "id http-запроса (X-Request-Id) и адрес клиента",
+                    "type": "string"
+                }
+            }
+        },
+        "models.BrokenLink": {
+            "type": "object",
+            "properties": {
+                "broken": {
+                    "type": "boolean"
+                },
+                "checkedAt": {
+                    "description": "время проверки, nil - ссылка еще не проверялась",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "ошибка запроса (таймаут, DNS и т.д.)",
+                    "type": "string"
+                },
+                "groupName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "redirectURL": {
+                    "description": "адрес, на который ведет ссылка после перенаправлений, nil - перенаправлений не было",
+                    "type": "string"
+                },
+                "region": {
+                    "description": "код страны ISO 3166-1, nil - ссылка доступна везде",
+                    "type": "string"
+                },
+                "songID": {
+                    "type": "string"
+                },
+                "songName": {
+                    "type": "string"
+                },
+                "statusCode": {
+                    "description": "код ответа, nil - ответ не получен",
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.DeletedSong": {
+            "type": "object",
+            "properties": {
+                "deletedAt": {
+                    "type": "string"
+                },
+                "groupName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "songName": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.FieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "from": {},
+                "to": {}
+            }
+        },
+        "models.Genre": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Group": {
+            "type": "object",
+            "properties": {
+                "groupName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.GroupWithDetail": {
+            "type": "object",
+            "properties": {
+                "groupName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "songCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Person": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.PersonSong": {
+            "type": "object",
+            "properties": {
+                "artists": {
+                    "description": "все исполнители песни, основной - первый",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Artist"
+                    }
+                },
+                "bpm": {
+                    "type": "integer"
+                },
+                "duration": {
+                    "description": "длительность в секундах",
+                    "type": "integer"
+                },
+                "explicit": {
+                    "description": "ненормативная лексика",
+                    "type": "boolean"
+                },
+                "groupName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isrc": {
+                    "description": "международный стандартный код записи",
+                    "type": "string"
+                },
+                "key": {
+                    "description": "тональность",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "язык текста (ISO 639-1)",
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "releaseDate": {
+                    "description": "дата релиза; если не задана явно, берется из альбома, nil - неизвестна",
+                    "type": "string"
+                },
+                "roles": {
+                    "description": "роли человека в песне",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "songName": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Revision": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "автор изменения, nil - неизвестен",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "groupName": {
+                    "type": "string"
+                },
+                "num": {
+                    "type": "integer"
+                },
+                "restoredFrom": {
+                    "description": "номер ревизии, из которой восстановлена данная",
+                    "type": "integer"
+                },
+                "songName": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.RevisionDiff": {
+            "type": "object",
+            "properties": {
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FieldChange"
+                    }
+                },
+                "from": {
+                    "type": "integer"
+                },
+                "to": {
+                    "type": "integer"
+                },
+                "verses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VerseChange"
+                    }
+                }
+            }
+        },
+        "models.RevisionWithDetail": {
+            "type": "object",
+            "properties": {
+                "author": {
+                    "description": "автор изменения, nil - неизвестен",
+                    "type": "string"
+                },
+                "bpm": {
+                    "type": "integer"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "duration": {
+                    "description": "длительность в секундах",
+                    "type": "integer"
+                },
+                "explicit": {
+                    "description": "ненормативная лексика",
+                    "type": "boolean"
+                },
+                "groupName": {
+                    "type": "string"
+                },
+                "isrc": {
+                    "description": "международный стандартный код записи",
+                    "type": "string"
+                },
+                "key": {
+                    "description": "тональность",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "язык текста (ISO 639-1)",
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "num": {
+                    "type": "integer"
+                },
+                "releaseDate": {
+                    "description": "дата релиза; если не задана явно, берется из альбома, nil - неизвестна",
+                    "type": "string"
+                },
+                "restoredFrom": {
+                    "description": "номер ревизии, из которой восстановлена данная",
+                    "type": "integer"
+                },
+                "songName": {
+                    "type": "string"
+                },
+                "verses": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Verse"
+                    }
+                }
+            }
+        },
+        "models.SearchResult": {
+            "type": "object",
+            "properties": {
+                "artists": {
+                    "description": "все исполнители песни, основной - первый",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Artist"
+                    }
+                },
+                "bpm": {
+                    "type": "integer"
+                },
+                "duration": {
+                    "description": "длительность в секундах",
+                    "type": "integer"
+                },
+                "explicit": {
+                    "description": "ненормативная лексика",
+                    "type": "boolean"
+                },
+                "groupName": {
+                    "type": "string"
+                },
+                "highlight": {
+                    "description": "куплет с выделенными совпадениями",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isrc": {
+                    "description": "международный стандартный код записи",
+                    "type": "string"
+                },
+                "key": {
+                    "description": "тональность",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "язык текста (ISO 639-1)",
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "number"
+                },
+                "releaseDate": {
+                    "description": "дата релиза; если не задана явно, берется из альбома, nil - неизвестна",
+                    "type": "string"
+                },
+                "songName": {
+                    "type": "string"
+                },
+                "verseNum": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.SongCredit": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SongDetailWithCredits": {
+            "type": "object",
+            "properties": {
+                "bpm": {
+                    "type": "integer"
+                },
+                "credits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SongCredit"
+                    }
+                },
+                "duration": {
+                    "description": "длительность в секундах",
+                    "type": "integer"
+                },
+                "explicit": {
+                    "description": "ненормативная лексика",
+                    "type": "boolean"
+                },
+                "isrc": {
+                    "description": "международный стандартный код записи",
+                    "type": "string"
+                },
+                "key": {
+                    "description": "тональность",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "язык текста (ISO 639-1)",
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "releaseDate": {
+                    "description": "дата релиза; если не задана явно, берется из альбома, nil - неизвестна",
+                    "type": "string"
+                }
+            }
+        },
+        "models.SongLink": {
+            "type": "object",
+            "properties": {
+                "broken": {
+                    "type": "boolean"
+                },
+                "checkedAt": {
+                    "description": "время проверки, nil - ссылка еще не проверялась",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "ошибка запроса (таймаут, DNS и т.д.)",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "redirectURL": {
+                    "description": "адрес, на который ведет ссылка после перенаправлений, nil - перенаправлений не было",
+                    "type": "string"
+                },
+                "region": {
+                    "description": "код страны ISO 3166-1, nil - ссылка доступна везде",
+                    "type": "string"
+                },
+                "statusCode": {
+                    "description": "код ответа, nil - ответ не получен",
+                    "type": "integer"
+                },
+                "type": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.SongWithDetail": {
+            "type": "object",
+            "properties": {
+                "artists": {
+                    "description": "все исполнители песни, основной - первый",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Artist"
+                    }
+                },
+                "bpm": {
+                    "type": "integer"
+                },
+                "duration": {
+                    "description": "длительность в секундах",
+                    "type": "integer"
+                },
+                "explicit": {
+                    "description": "ненормативная лексика",
+                    "type": "boolean"
+                },
+                "groupName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isrc": {
+                    "description": "международный стандартный код записи",
+                    "type": "string"
+                },
+                "key": {
+                    "description": "тональность",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "язык текста (ISO 639-1)",
+                    "type": "string"
+                },
+                "link": {
+                    "type": "string"
+                },
+                "releaseDate": {
+                    "description": "дата релиза; если не задана явно, берется из альбома, nil - неизвестна",
+                    "type": "string"
+                },
+                "songName": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.TagCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "tag": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.Track": {
+            "type": "object",
+            "properties": {
+                "artists": {
+                    "description": "все исполнители песни, основной - первый",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.Artist"
+                    }
+                },
+                "bpm": {
+                    "type": "integer"
+                },
+                "discNum": {
+                    "type": "integer"
+                },
+                "duration": {
+                    "description": "длительность в секундах",
+                    "type": "integer"
+                },
+                "explicit": {
+                    "description": "ненормативная лексика",
+                    "type": "boolean"
+                },
+                "groupName": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "isrc": {
+                    "description": "международный стандартный код записи",
+                    "type": "string"
+                },
+                "key": {
+                    "description": "тональность",
+                    "type": "string"
+                },
+                "language": {
+                    "description": "язык текста (ISO 639-1)",
+                    "type": "string"
+                },
+                "link": {
                     "type": "string"
                 },
                 "releaseDate": {
+                    "description": "дата релиза; если не задана явно, берется из альбома, nil - неизвестна",
                     "type": "string"
                 },
                 "songName": {
                     "type": "string"
+                },
+                "trackNum": {
+                    "type": "integer"
+                }
+            }
+        },
+        "models.Verse": {
+            "type": "object",
+            "properties": {
+                "num": {
+                    "type": "integer"
+                },
+                "repeatOf": {
+                    "description": "номер куплета, который повторяет данный",
+                    "type": "integer"
+                },
+                "section": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.VerseChange": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "$ref": "#/definitions/models.Verse"
+                },
+                "op": {
+                    "type": "string"
+                },
+                "to": {
+                    "$ref": "#/definitions/models.Verse"
+                }
+            }
+        },
+        "v2.createdResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "v2.restoredResponse": {
+            "type": "object",
+            "properties": {
+                "revision": {
+                    "type": "integer"
                 }
             }
         }
@@ -3,15 +3,25 @@ package app
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
+	"time"
 
 	"github.com/cutlery47/music-storage/internal/config"
+	"github.com/cutlery47/music-storage/internal/controller/http/common"
 	v1 "github.com/cutlery47/music-storage/internal/controller/http/v1"
+	v2 "github.com/cutlery47/music-storage/internal/controller/http/v2"
+	"github.com/cutlery47/music-storage/internal/linkcheck"
 	"github.com/cutlery47/music-storage/internal/repository"
 	"github.com/cutlery47/music-storage/internal/service"
+	"github.com/cutlery47/music-storage/internal/songinfo"
+	"github.com/cutlery47/music-storage/internal/trash"
 	"github.com/cutlery47/music-storage/internal/utils"
+	"github.com/cutlery47/music-storage/pkg/httpclient"
 	"github.com/cutlery47/music-storage/pkg/httpserver"
 	"github.com/cutlery47/music-storage/pkg/logger"
 	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
 	"github.com/sirupsen/logrus"
 )
 
@@ -50,12 +60,47 @@ func Run() error {
 		return fmt.Errorf("error when connecting to the db: %v", err)
 	}
 
+	// автозаполнение сведений о песнях отключено, если адрес сервиса не задан
+	var info service.SongInfoProvider
+	if config.SongInfoURL != "" {
+		logrus.Debug("initializing song info client...")
+		info = songinfo.New(config.SongInfoConfig, outboundClient(config.OutboundConfig, config.SongInfoTimeout))
+	}
+
 	logrus.Debug("initializing service...")
-	srv := service.NewMusicService(repo)
+	srv := service.NewMusicService(repo, info, config.SearchConfig)
+
+	// фоновые процессы останавливаются вместе с http-сервером
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	if config.LinkCheckEnabled {
+		logrus.Debug("initializing link checker...")
+		checker := linkcheck.New(repo, config.LinkCheckConfig, errLog)
+		go checker.Run(ctx)
+	}
+
+	if config.TrashPurgeEnabled {
+		logrus.Debug("initializing trash purger...")
+		purger := trash.New(repo, config.TrashConfig, errLog)
+		go purger.Run(ctx)
+	}
+
+	trustedProxies, err := parseSubnets(config.TrustedProxies)
+	if err != nil {
+		return fmt.Errorf("error when parsing trusted proxies: %v", err)
+	}
 
 	logrus.Debug("initializing controller...")
 	echo := echo.New()
+	echo.IPExtractor = ipExtractor(trustedProxies)
+	// общие для всех версий API middleware
+	echo.Use(middleware.Recover())
+	echo.Use(middleware.RequestID())
+	echo.Use(common.RequestInfoMiddleware())
+	echo.Use(common.AuthorMiddleware(trustedProxies))
 	v1.NewController(echo, srv, infoLog, errLog)
+	v2.NewController(echo, srv, infoLog, errLog)
 
 	logrus.Debug("initializing http server...")
 	httpserver := httpserver.New(
@@ -68,3 +113,49 @@ func Run() error {
 
 	return httpserver.Run(ctx)
 }
+
+// Клиент для исходящих запросов к одному внешнему сервису.
+// У каждого сервиса свой клиент, чтобы отказы одного не размыкали цепь другого
+func outboundClient(conf config.OutboundConfig, timeout time.Duration) *httpclient.Client {
+	return httpclient.New(
+		httpclient.Timeout(timeout),
+		httpclient.CircuitBreaker(conf.OutboundBreakerThreshold, conf.OutboundBreakerTimeout),
+		httpclient.Bulkhead(conf.OutboundMaxConcurrent, conf.OutboundQueueTimeout),
+		httpclient.Cache(conf.OutboundCacheTTL, conf.OutboundCacheSize),
+	)
+}
+
+// Разбирает подсети доверенных прокси
+func parseSubnets(subnets []string) ([]*net.IPNet, error) {
+	var res []*net.IPNet
+	for _, subnet := range subnets {
+		_, ipNet, err := net.ParseCIDR(strings.TrimSpace(subnet))
+		if err != nil {
+			return nil, fmt.Errorf("net.ParseCIDR: %v", err)
+		}
+		res = append(res, ipNet)
+	}
+	return res, nil
+}
+
+// Способ определения адреса клиента. Без доверенных прокси заголовки X-Forwarded-For и X-Real-IP
+// игнорируются, иначе клиент мог бы подделать адрес, сохраняемый в журнале аудита
+func ipExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	var ranges []echo.TrustOption
+	for _, ipNet := range trustedProxies {
+		ranges = append(ranges, echo.TrustIPRange(ipNet))
+	}
+
+	// по умолчанию echo доверяет всем локальным и частным адресам
+	opts := append([]echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}, ranges...)
+
+	return echo.ExtractIPFromXFFHeader(opts...)
+}
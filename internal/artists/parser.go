@@ -0,0 +1,42 @@
+package artists
+
+import (
+	"regexp"
+	"strings"
+)
+
+// отметка приглашенных исполнителей: "A feat. B", "A ft. B", "A (featuring B)", "A [feat. B]"
+var featRe = regexp.MustCompile(`(?i)(\s+[\(\[]?|\s*[\(\[])(feat\.?|ft\.?|featuring)\s+`)
+
+// разделители в списке приглашенных исполнителей: "B, C & D"
+var separatorRe = regexp.MustCompile(`\s*,\s*|\s+&\s+`)
+
+// Разбирает название группы вида "A feat. B, C & D" на основного исполнителя (A)
+// и приглашенных (B, C, D). Названия без отметки feat. возвращаются без изменений
+func Parse(name string) (string, []string) {
+	name = strings.TrimSpace(name)
+
+	loc := featRe.FindStringIndex(name)
+	if loc == nil || loc[0] == 0 {
+		return name, nil
+	}
+
+	primary := strings.TrimSpace(name[:loc[0]])
+	rest := strings.TrimSpace(name[loc[1]:])
+	// закрывающая скобка после списка приглашенных
+	rest = strings.TrimSpace(strings.TrimRight(rest, ")]"))
+
+	var featured []string
+	seen := map[string]bool{strings.ToLower(primary): true}
+
+	for _, artist := range separatorRe.Split(rest, -1) {
+		artist = strings.TrimSpace(artist)
+		if artist == "" || seen[strings.ToLower(artist)] {
+			continue
+		}
+		seen[strings.ToLower(artist)] = true
+		featured = append(featured, artist)
+	}
+
+	return primary, featured
+}
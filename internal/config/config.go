@@ -12,6 +12,11 @@ type Config struct {
 	HttpConfig
 	PostgresConfig
 	LoggerConfig
+	SearchConfig
+	LinkCheckConfig
+	SongInfoConfig
+	OutboundConfig
+	TrashConfig
 }
 
 type Mode struct {
@@ -24,6 +29,10 @@ type HttpConfig struct {
 	ReadTimeout     time.Duration `env:"HTTP_READ_TIMEOUT"`
 	WriteTimeout    time.Duration `env:"HTTP_WRITE_TIMEOUT"`
 	ShutdownTimeout time.Duration `env:"HTTP_SHUTDOWN_TIMEOUT"`
+	// подсети доверенных прокси через запятую. Адрес клиента берется из X-Forwarded-For,
+	// только если запрос пришел от доверенного прокси; пусто - используется адрес соединения.
+	// Если прокси заданы, автор изменений (X-Author) принимается только от них
+	TrustedProxies []string `env:"HTTP_TRUSTED_PROXIES" env-separator:","`
 }
 
 type PostgresConfig struct {
@@ -42,6 +51,64 @@ type LoggerConfig struct {
 	ErrorPath string `env:"ERROR_LOGS_PATH"`
 }
 
+type SearchConfig struct {
+	// язык полнотекстового поиска по умолчанию (english, russian, simple)
+	SearchLanguage string `env:"SEARCH_LANGUAGE"`
+}
+
+type LinkCheckConfig struct {
+	// включает фоновую проверку ссылок
+	LinkCheckEnabled bool `env:"LINK_CHECK_ENABLED"`
+	// период между проходами проверки
+	LinkCheckInterval time.Duration `env:"LINK_CHECK_INTERVAL"`
+	// ссылка перепроверяется, если с последней проверки прошло больше этого времени
+	LinkCheckRecheckAfter time.Duration `env:"LINK_CHECK_RECHECK_AFTER"`
+	// максимальное количество ссылок за один проход
+	LinkCheckBatchSize int `env:"LINK_CHECK_BATCH_SIZE"`
+	// количество одновременных запросов
+	LinkCheckWorkers int `env:"LINK_CHECK_WORKERS"`
+	// максимальное количество запросов в секунду к одному хосту
+	LinkCheckHostRate float64 `env:"LINK_CHECK_HOST_RATE"`
+	// таймаут одного запроса
+	LinkCheckTimeout time.Duration `env:"LINK_CHECK_TIMEOUT"`
+}
+
+type SongInfoConfig struct {
+	// адрес внешнего сервиса сведений о песнях, пустая строка - автозаполнение отключено
+	SongInfoURL string `env:"SONG_INFO_URL"`
+	// таймаут одного запроса
+	SongInfoTimeout time.Duration `env:"SONG_INFO_TIMEOUT"`
+	// количество повторных попыток, 0 - без повторов, отрицательное - значение по умолчанию
+	SongInfoRetries int `env:"SONG_INFO_RETRIES" env-default:"-1"`
+	// начальная задержка между попытками, удваивается с каждой попыткой
+	SongInfoBackoff time.Duration `env:"SONG_INFO_BACKOFF"`
+}
+
+// Настройки исходящих запросов к внешним сервисам, общие для всех клиентов pkg/httpclient
+type OutboundConfig struct {
+	// количество отказов подряд, после которого цепь размыкается, 0 - предохранитель отключен
+	OutboundBreakerThreshold int `env:"OUTBOUND_BREAKER_THRESHOLD"`
+	// время, на которое размыкается цепь
+	OutboundBreakerTimeout time.Duration `env:"OUTBOUND_BREAKER_TIMEOUT"`
+	// максимальное количество одновременных запросов к одному сервису
+	OutboundMaxConcurrent int `env:"OUTBOUND_MAX_CONCURRENT"`
+	// сколько запрос может ждать свободного места
+	OutboundQueueTimeout time.Duration `env:"OUTBOUND_QUEUE_TIMEOUT"`
+	// время жизни закэшированного ответа, 0 - кэширование отключено
+	OutboundCacheTTL time.Duration `env:"OUTBOUND_CACHE_TTL"`
+	// максимальное количество закэшированных ответов
+	OutboundCacheSize int `env:"OUTBOUND_CACHE_SIZE"`
+}
+
+type TrashConfig struct {
+	// включает фоновую очистку корзины
+	TrashPurgeEnabled bool `env:"TRASH_PURGE_ENABLED"`
+	// сколько дней песня хранится в корзине, прежде чем удаляется окончательно
+	TrashRetentionDays int `env:"TRASH_RETENTION_DAYS"`
+	// период между проходами очистки
+	TrashPurgeInterval time.Duration `env:"TRASH_PURGE_INTERVAL"`
+}
+
 func New() (*Config, error) {
 	if err := godotenv.Load(".env"); err != nil {
 		return nil, fmt.Errorf("godotenv.Load: %v", err)
@@ -53,16 +120,21 @@ func New() (*Config, error) {
 	}
 
 	var (
-		pgConf   PostgresConfig
-		logConf  LoggerConfig
-		httpConf HttpConfig
+		pgConf     PostgresConfig
+		logConf    LoggerConfig
+		httpConf   HttpConfig
+		searchConf SearchConfig
+		linkConf   LinkCheckConfig
+		infoConf   SongInfoConfig
+		outConf    OutboundConfig
+		trashConf  TrashConfig
 	)
 
 	switch mode.Mode {
 	case "DEV":
-		setDevConfig(&pgConf, &logConf, &httpConf)
+		setDevConfig(&pgConf, &logConf, &httpConf, &searchConf, &linkConf, &infoConf, &outConf, &trashConf)
 	case "PROD":
-		if err := setProdConfig(&pgConf, &logConf, &httpConf); err != nil {
+		if err := setProdConfig(&pgConf, &logConf, &httpConf, &searchConf, &linkConf, &infoConf, &outConf, &trashConf); err != nil {
 			return nil, fmt.Errorf("setProdConfig: %v", err)
 		}
 	default:
@@ -70,13 +142,18 @@ func New() (*Config, error) {
 	}
 
 	return &Config{
-		PostgresConfig: pgConf,
-		HttpConfig:     httpConf,
-		LoggerConfig:   logConf,
+		PostgresConfig:  pgConf,
+		HttpConfig:      httpConf,
+		LoggerConfig:    logConf,
+		SearchConfig:    searchConf,
+		LinkCheckConfig: linkConf,
+		SongInfoConfig:  infoConf,
+		OutboundConfig:  outConf,
+		TrashConfig:     trashConf,
 	}, nil
 }
 
-func setProdConfig(pgConf *PostgresConfig, logConf *LoggerConfig, httpConf *HttpConfig) error {
+func setProdConfig(pgConf *PostgresConfig, logConf *LoggerConfig, httpConf *HttpConfig, searchConf *SearchConfig, linkConf *LinkCheckConfig, infoConf *SongInfoConfig, outConf *OutboundConfig, trashConf *TrashConfig) error {
 	if err := cleanenv.ReadEnv(pgConf); err != nil {
 		return fmt.Errorf("couldn't read postgres config: %v", err)
 	}
@@ -89,10 +166,30 @@ func setProdConfig(pgConf *PostgresConfig, logConf *LoggerConfig, httpConf *Http
 		return fmt.Errorf("coundn't read logger config: %v", err)
 	}
 
+	if err := cleanenv.ReadEnv(searchConf); err != nil {
+		return fmt.Errorf("couldn't read search config: %v", err)
+	}
+
+	if err := cleanenv.ReadEnv(linkConf); err != nil {
+		return fmt.Errorf("couldn't read link check config: %v", err)
+	}
+
+	if err := cleanenv.ReadEnv(infoConf); err != nil {
+		return fmt.Errorf("couldn't read song info config: %v", err)
+	}
+
+	if err := cleanenv.ReadEnv(outConf); err != nil {
+		return fmt.Errorf("couldn't read outbound config: %v", err)
+	}
+
+	if err := cleanenv.ReadEnv(trashConf); err != nil {
+		return fmt.Errorf("couldn't read trash config: %v", err)
+	}
+
 	return nil
 }
 
-func setDevConfig(pgConf *PostgresConfig, logConf *LoggerConfig, httpConf *HttpConfig) {
+func setDevConfig(pgConf *PostgresConfig, logConf *LoggerConfig, httpConf *HttpConfig, searchConf *SearchConfig, linkConf *LinkCheckConfig, infoConf *SongInfoConfig, outConf *OutboundConfig, trashConf *TrashConfig) {
 	pgConf.PostgresDB = "music"
 	pgConf.PostgresHost = "localhost"
 	pgConf.PostgresPort = "5432"
@@ -110,4 +207,32 @@ func setDevConfig(pgConf *PostgresConfig, logConf *LoggerConfig, httpConf *HttpC
 	httpConf.ReadTimeout = 3 * time.Second
 	httpConf.WriteTimeout = 3 * time.Second
 	httpConf.ShutdownTimeout = 3 * time.Second
+
+	searchConf.SearchLanguage = "english"
+
+	linkConf.LinkCheckEnabled = true
+	linkConf.LinkCheckInterval = 10 * time.Minute
+	linkConf.LinkCheckRecheckAfter = 24 * time.Hour
+	linkConf.LinkCheckBatchSize = 100
+	linkConf.LinkCheckWorkers = 4
+	linkConf.LinkCheckHostRate = 1
+	linkConf.LinkCheckTimeout = 5 * time.Second
+
+	// автозаполнение отключено; для локальной проверки можно запустить cmd/songinfo-mock
+	// и указать адрес http://localhost:8081
+	infoConf.SongInfoURL = ""
+	infoConf.SongInfoTimeout = 3 * time.Second
+	infoConf.SongInfoRetries = 2
+	infoConf.SongInfoBackoff = 200 * time.Millisecond
+
+	outConf.OutboundBreakerThreshold = 5
+	outConf.OutboundBreakerTimeout = 30 * time.Second
+	outConf.OutboundMaxConcurrent = 10
+	outConf.OutboundQueueTimeout = 500 * time.Millisecond
+	outConf.OutboundCacheTTL = 10 * time.Minute
+	outConf.OutboundCacheSize = 1000
+
+	trashConf.TrashPurgeEnabled = true
+	trashConf.TrashRetentionDays = 30
+	trashConf.TrashPurgeInterval = time.Hour
 }
@@ -0,0 +1,48 @@
+package common
+
+import (
+	"errors"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/cutlery47/music-storage/internal/repository"
+	"github.com/cutlery47/music-storage/internal/service"
+	"github.com/cutlery47/music-storage/internal/songinfo"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+var errMap = map[error]*echo.HTTPError{
+	repository.ErrNotFound:      echo.ErrNotFound,
+	repository.ErrAlreadyExists: echo.ErrBadRequest,
+	repository.ErrBadLanguage:   echo.ErrBadRequest,
+	repository.ErrBadCursor:     echo.ErrBadRequest,
+	repository.ErrUnknownGenre:  echo.ErrBadRequest,
+	repository.ErrPrimaryLink:   echo.ErrBadRequest,
+	repository.ErrGroupNotEmpty: echo.ErrConflict,
+	models.ErrBadMetadata:       echo.ErrBadRequest,
+	service.ErrIncompleteSong:   echo.ErrBadRequest,
+	songinfo.ErrUnavailable:     echo.ErrServiceUnavailable,
+}
+
+// Преобразует ошибки сервиса в http-ошибки, общие для всех версий API
+type ErrMapper struct {
+	errLog *logrus.Logger
+}
+
+func NewErrMapper(errLog *logrus.Logger) *ErrMapper {
+	return &ErrMapper{
+		errLog: errLog,
+	}
+}
+
+func (e ErrMapper) Map(err error) *echo.HTTPError {
+	for target, httpErr := range errMap {
+		if errors.Is(err, target) {
+			// создаем новую ошибку, чтобы не изменять общие echo.Err*
+			return echo.NewHTTPError(httpErr.Code, err.Error())
+		}
+	}
+
+	e.errLog.Error(err.Error())
+	return echo.ErrInternalServerError
+}
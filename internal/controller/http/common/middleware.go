@@ -0,0 +1,107 @@
+package common
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/sirupsen/logrus"
+)
+
+func RequestLoggerMiddleware(infoLog *logrus.Logger) echo.MiddlewareFunc {
+	return middleware.RequestLoggerWithConfig(
+		middleware.RequestLoggerConfig{
+			LogMethod:   true,
+			LogStatus:   true,
+			LogRemoteIP: true,
+			LogURI:      true,
+			LogError:    true,
+			LogValuesFunc: func(c echo.Context, v middleware.RequestLoggerValues) error {
+				infoLog.WithFields(logrus.Fields{
+					"method": v.Method,
+					"URI":    v.URI,
+					"status": v.Status,
+					"ip":     v.RemoteIP,
+					"error":  v.Error,
+				}).Info("request")
+				return nil
+			},
+		},
+	)
+}
+
+// заголовок с именем автора изменений
+const HeaderAuthor = "X-Author"
+
+// Передает автора изменений из заголовка X-Author в контекст запроса; без заголовка автор неизвестен.
+// Сервис не аутентифицирует автора. Если заданы доверенные прокси, заголовок принимается только
+// от них (его выставляет проверивший пользователя шлюз), иначе автора указывает сам клиент
+func AuthorMiddleware(trustedProxies []*net.IPNet) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			author := strings.TrimSpace(c.Request().Header.Get(HeaderAuthor))
+			if author == "" || !fromTrusted(c.Request(), trustedProxies) {
+				return next(c)
+			}
+
+			if len(author) > maxStringLen {
+				return echo.NewHTTPError(400, fmt.Sprintf("%v should not be longer than %v characters", HeaderAuthor, maxStringLen))
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(models.WithAuthor(req.Context(), author)))
+
+			return next(c)
+		}
+	}
+}
+
+// Пришел ли запрос напрямую от доверенного прокси; без доверенных прокси доверяется любой запрос
+func fromTrusted(req *http.Request, trustedProxies []*net.IPNet) bool {
+	if len(trustedProxies) == 0 {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(req.RemoteAddr)
+	if err != nil {
+		host = req.RemoteAddr
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, ipNet := range trustedProxies {
+		if ipNet.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Передает в контекст запроса его id и адрес клиента для журнала аудита.
+// Должен стоять после middleware.RequestID, который выставляет X-Request-Id
+func RequestInfoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			id := c.Response().Header().Get(echo.HeaderXRequestID)
+			if id == "" {
+				id = c.Request().Header.Get(echo.HeaderXRequestID)
+			}
+
+			req := c.Request()
+			c.SetRequest(req.WithContext(models.WithRequest(req.Context(), models.RequestInfo{
+				ID: id,
+				IP: c.RealIP(),
+			})))
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package common
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+type mediaRange struct {
+	mediaType string
+	q         float64
+}
+
+// Проверяет, предпочитает ли клиент text/plain вместо application/json согласно заголовку Accept.
+// При равных весах (и при отсутствии заголовка) выбирается json
+func PrefersPlainText(c echo.Context) bool {
+	accept := c.Request().Header.Get(echo.HeaderAccept)
+	if accept == "" {
+		return false
+	}
+
+	var ranges []mediaRange
+	for _, part := range strings.Split(accept, ",") {
+		ranges = append(ranges, parseMediaRange(part))
+	}
+
+	return acceptWeight(ranges, echo.MIMETextPlain) > acceptWeight(ranges, echo.MIMEApplicationJSON)
+}
+
+// вес типа определяется наиболее конкретным подходящим диапазоном (RFC 7231, 5.3.2)
+func acceptWeight(ranges []mediaRange, mediaType string) float64 {
+	typ, _, _ := strings.Cut(mediaType, "/")
+
+	weight, specificity := 0.0, -1
+	for _, r := range ranges {
+		spec := -1
+		switch r.mediaType {
+		case mediaType:
+			spec = 2
+		case typ + "/*":
+			spec = 1
+		case "*/*":
+			spec = 0
+		}
+
+		if spec > specificity {
+			weight, specificity = r.q, spec
+		}
+	}
+
+	return weight
+}
+
+// разбирает элемент заголовка Accept вида "text/plain;q=0.8"
+func parseMediaRange(part string) mediaRange {
+	params := strings.Split(part, ";")
+	r := mediaRange{
+		mediaType: strings.ToLower(strings.TrimSpace(params[0])),
+		q:         1,
+	}
+
+	for _, param := range params[1:] {
+		key, val, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if ok && strings.TrimSpace(key) == "q" {
+			if q, err := strconv.ParseFloat(strings.TrimSpace(val), 64); err == nil {
+				r.q = q
+			}
+		}
+	}
+
+	return r
+}
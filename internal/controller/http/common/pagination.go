@@ -0,0 +1,99 @@
+package common
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Страница списка с метаданными пагинации
+type Page[T any] struct {
+	Items []T `json:"items"`
+	PageMeta
+}
+
+// Метаданные пагинации.
+// В режиме курсора offset не возвращается, а вместо next_offset используются next_cursor и prev_cursor
+type PageMeta struct {
+	Total      int     `json:"total"`
+	Limit      int     `json:"limit"`
+	Offset     *int    `json:"offset,omitempty"`
+	HasMore    bool    `json:"has_more"`
+	NextOffset *int    `json:"next_offset,omitempty"`
+	NextCursor *string `json:"next_cursor,omitempty"`
+	PrevCursor *string `json:"prev_cursor,omitempty"`
+}
+
+func OffsetPage[T any](items []T, total, limit, offset int) Page[T] {
+	page := Page[T]{
+		Items: items,
+		PageMeta: PageMeta{
+			Total:  total,
+			Limit:  limit,
+			Offset: &offset,
+		},
+	}
+
+	if next := offset + len(items); len(items) > 0 && next < total {
+		page.HasMore = true
+		page.NextOffset = &next
+	}
+
+	return page
+}
+
+func CursorPage[T any](items []T, total, limit int, next, prev *string) Page[T] {
+	return Page[T]{
+		Items: items,
+		PageMeta: PageMeta{
+			Total:      total,
+			Limit:      limit,
+			HasMore:    next != nil,
+			NextCursor: next,
+			PrevCursor: prev,
+		},
+	}
+}
+
+// Выставляет заголовок Link (RFC 8288) со ссылками на соседние страницы.
+// Ссылки строятся из текущего запроса заменой offset или cursor
+func (p PageMeta) SetLinks(c echo.Context) {
+	var links []string
+
+	link := func(rel, param, val string) {
+		u := *c.Request().URL
+		query := u.Query()
+		query.Set(param, val)
+		if param == "cursor" {
+			query.Del("offset")
+		}
+		u.RawQuery = query.Encode()
+		links = append(links, fmt.Sprintf(`<%v>; rel="%v"`, u.RequestURI(), rel))
+	}
+
+	if p.Offset != nil {
+		offset := *p.Offset
+		link("first", "offset", "0")
+		if offset > 0 && p.Limit > 0 {
+			link("prev", "offset", strconv.Itoa(max(offset-p.Limit, 0)))
+		}
+		if p.NextOffset != nil {
+			link("next", "offset", strconv.Itoa(*p.NextOffset))
+		}
+		if p.Total > 0 && p.Limit > 0 {
+			link("last", "offset", strconv.Itoa((p.Total-1)/p.Limit*p.Limit))
+		}
+	} else {
+		link("first", "cursor", "")
+		if p.PrevCursor != nil {
+			link("prev", "cursor", *p.PrevCursor)
+		}
+		if p.NextCursor != nil {
+			link("next", "cursor", *p.NextCursor)
+		}
+	}
+
+	c.Response().Header().Set("Link", strings.Join(links, ", "))
+}
@@ -0,0 +1,174 @@
+package common
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cutlery47/music-storage/internal/lyrics"
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrBadQueryPagination = echo.NewHTTPError(400, "limit should be a positive integer and offset a non-negative integer")
+	ErrBadQueryFlag       = echo.NewHTTPError(400, "couldn't parse boolean query params...")
+	ErrBadQuerySection    = echo.NewHTTPError(400, fmt.Sprintf("section should be one of: %v", strings.Join(lyrics.Sections, ", ")))
+	ErrBadQuerySort       = echo.NewHTTPError(400, fmt.Sprintf("sort should be a comma-separated list of unique fields (%v), each optionally prefixed with - for descending order", strings.Join(models.SortFields, ", ")))
+	ErrBadQueryRole       = echo.NewHTTPError(400, fmt.Sprintf("role should be one of: %v", strings.Join(models.CreditRoles, ", ")))
+	ErrBadQuerySet        = echo.NewHTTPError(400, fmt.Sprintf("tagMatch and genreMatch should be one of: %v", strings.Join(models.SetMatchModes, ", ")))
+	ErrBadQueryList       = echo.NewHTTPError(400, fmt.Sprintf("list items should not be longer than %v characters", maxStringLen))
+)
+
+// Разбирает размер страницы: положительное целое
+func ParseLimit(params url.Values) (int, error) {
+	limit, err := strconv.Atoi(params.Get("limit"))
+	if err != nil || limit <= 0 {
+		return 0, ErrBadQueryPagination
+	}
+	return limit, nil
+}
+
+// Разбирает смещение страницы: неотрицательное целое
+func ParseOffset(params url.Values) (int, error) {
+	offset, err := strconv.Atoi(params.Get("offset"))
+	if err != nil || offset < 0 {
+		return 0, ErrBadQueryPagination
+	}
+	return offset, nil
+}
+
+// Параметры получения страницы текста песни
+type TextQuery struct {
+	Limit  int
+	Offset int
+	// курсор страницы, nil - пагинация по смещению
+	Cursor *string
+	Filter models.TextFilter
+	// добавлять метки секций в обычный текст
+	Labels bool
+}
+
+// Разбирает параметры получения текста: limit, offset или cursor, section, collapse и labels
+func ParseTextQuery(params url.Values) (query TextQuery, err error) {
+	if query.Limit, err = ParseLimit(params); err != nil {
+		return TextQuery{}, ErrBadQueryPagination
+	}
+
+	// пагинация по курсору; пустой курсор - первая страница
+	if params.Has("cursor") {
+		cursor := params.Get("cursor")
+		query.Cursor = &cursor
+	} else if query.Offset, err = ParseOffset(params); err != nil {
+		return TextQuery{}, ErrBadQueryPagination
+	}
+
+	if params.Has("section") {
+		section := strings.ToLower(params.Get("section"))
+		if !lyrics.IsSection(section) {
+			return TextQuery{}, ErrBadQuerySection
+		}
+		query.Filter.Section = &section
+	}
+
+	if params.Has("collapse") {
+		if query.Filter.Collapse, err = strconv.ParseBool(params.Get("collapse")); err != nil {
+			return TextQuery{}, ErrBadQueryFlag
+		}
+	}
+
+	if params.Has("labels") {
+		if query.Labels, err = strconv.ParseBool(params.Get("labels")); err != nil {
+			return TextQuery{}, ErrBadQueryFlag
+		}
+	}
+
+	return query, nil
+}
+
+// Разбирает параметр сортировки вида "group,-releaseDate": поля через запятую,
+// минус перед полем означает сортировку по убыванию
+func ParseSort(param string) ([]models.SortKey, error) {
+	var keys []models.SortKey
+	seen := map[string]bool{}
+
+	for _, field := range strings.Split(param, ",") {
+		field = strings.TrimSpace(field)
+
+		key := models.SortKey{}
+		if strings.HasPrefix(field, "-") {
+			key.Desc = true
+			field = strings.TrimPrefix(field, "-")
+		}
+		key.Field = field
+
+		if !slices.Contains(models.SortFields, field) || seen[field] {
+			return nil, ErrBadQuerySort
+		}
+		seen[field] = true
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// Разбирает необязательный параметр role (роль участника песни)
+func ParseCreditRole(params url.Values) (*string, error) {
+	if !params.Has("role") {
+		return nil, nil
+	}
+
+	role := strings.ToLower(params.Get("role"))
+	if !slices.Contains(models.CreditRoles, role) {
+		return nil, ErrBadQueryRole
+	}
+
+	return &role, nil
+}
+
+// Разбирает список тегов или жанров через запятую, например "rock,indie"
+func ParseList(param string) ([]string, error) {
+	names, err := NormalizeNames(strings.Split(param, ","))
+	if err != nil {
+		return nil, ErrBadQueryList
+	}
+
+	return names, nil
+}
+
+// Приводит названия тегов или жанров к нижнему регистру, отбрасывая пустые и повторяющиеся
+func NormalizeNames(names []string) ([]string, error) {
+	normalized := []string{}
+	seen := map[string]bool{}
+
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" || seen[name] {
+			continue
+		}
+		if len(name) > maxStringLen {
+			return nil, echo.NewHTTPError(400, fmt.Sprintf("names should not be longer than %v characters", maxStringLen))
+		}
+		seen[name] = true
+		normalized = append(normalized, name)
+	}
+
+	return normalized, nil
+}
+
+// Разбирает способ сопоставления списка (all или any). По умолчанию - all
+func ParseSetMatch(params url.Values, key string) (string, error) {
+	if !params.Has(key) {
+		return models.SetMatchAll, nil
+	}
+
+	match := strings.ToLower(params.Get(key))
+	if !slices.Contains(models.SetMatchModes, match) {
+		return "", ErrBadQuerySet
+	}
+
+	return match, nil
+}
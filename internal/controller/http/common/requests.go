@@ -0,0 +1,617 @@
+package common
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/labstack/echo/v4"
+)
+
+// максимальная длина строк, хранимых в music_schema.string
+const maxStringLen = 256
+
+// Тело запроса на создание/обновление песни.
+// Принимается как в application/json, так и в form-data.
+// При создании незаданные дата релиза, ссылка и текст запрашиваются у сервиса сведений о песнях
+type SongRequest struct {
+	Group       string `json:"group" form:"group" example:"The Beatles"`
+	Song        string `json:"song" form:"song" example:"Hey Jude"`
+	ReleaseDate string `json:"releaseDate" form:"releaseDate" example:"1968-08-26"`
+	Link        string `json:"link" form:"link" example:"https://example.com/hey_jude"`
+	Text        string `json:"text" form:"text" example:"Hey Jude, dont make it bad"`
+	// приглашенные исполнители; также выделяются из названия группы вида "A feat. B"
+	Featured []string `json:"featured" form:"featured"`
+	// авторы ремикса
+	Remixers []string `json:"remixers" form:"remixers"`
+	// длительность в секундах, 0 - неизвестна
+	Duration int    `json:"duration" form:"duration" example:"431"`
+	ISRC     string `json:"isrc" form:"isrc" example:"GB-AYE-68-00011"`
+	// код языка ISO 639-1
+	Language string `json:"language" form:"language" example:"en"`
+	Explicit bool   `json:"explicit" form:"explicit"`
+	// темп, 0 - неизвестен
+	BPM int    `json:"bpm" form:"bpm" example:"74"`
+	Key string `json:"key" form:"key" example:"F"`
+}
+
+func (sr SongRequest) Validate() error {
+	var missing []string
+	for _, field := range []struct{ name, val string }{
+		{"group", sr.Group},
+		{"song", sr.Song},
+	} {
+		if strings.TrimSpace(field.val) == "" {
+			missing = append(missing, field.name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return echo.NewHTTPError(400, fmt.Sprintf("missing required fields: %v", strings.Join(missing, ", ")))
+	}
+
+	for _, field := range []struct{ name, val string }{
+		{"group", sr.Group},
+		{"song", sr.Song},
+		{"link", sr.Link},
+	} {
+		if len(field.val) > maxStringLen {
+			return echo.NewHTTPError(400, fmt.Sprintf("%v should not be longer than %v characters", field.name, maxStringLen))
+		}
+	}
+
+	for _, artist := range append(slices.Clone(sr.Featured), sr.Remixers...) {
+		if strings.TrimSpace(artist) == "" {
+			return echo.NewHTTPError(400, "featured and remixers should not contain empty names")
+		}
+		if len(artist) > maxStringLen {
+			return echo.NewHTTPError(400, fmt.Sprintf("artist names should not be longer than %v characters", maxStringLen))
+		}
+	}
+
+	// ссылка необязательна: без нее ссылка запрашивается у сервиса сведений о песнях
+	if sr.Link != "" {
+		if err := validateURL("link", sr.Link); err != nil {
+			return err
+		}
+	}
+
+	// дата релиза необязательна: без нее дата берется из альбома
+	if sr.ReleaseDate != "" {
+		if _, err := time.Parse(time.DateOnly, sr.ReleaseDate); err != nil {
+			return echo.NewHTTPError(400, "releaseDate should be formatted as YYYY-MM-DD")
+		}
+	}
+
+	return nil
+}
+
+// Валидирует запрос и преобразует его в модель песни
+func (sr SongRequest) ToModel() (models.SongWithDetailPlain, error) {
+	if err := sr.Validate(); err != nil {
+		return models.SongWithDetailPlain{}, err
+	}
+
+	var releaseDate *time.Time
+	if sr.ReleaseDate != "" {
+		parsed, _ := time.Parse(time.DateOnly, sr.ReleaseDate)
+		releaseDate = &parsed
+	}
+
+	return models.SongWithDetailPlain{
+		Song: models.Song{
+			GroupName: sr.Group,
+			SongName:  sr.Song,
+		},
+		SongDetail: models.SongDetail{
+			ReleaseDate: releaseDate,
+			Link:        sr.Link,
+			SongMeta:    sr.meta(),
+		},
+		Credits: append(
+			credits(sr.Featured, models.RoleFeatured),
+			credits(sr.Remixers, models.RoleRemixer)...,
+		),
+		Text: sr.Text,
+	}, nil
+}
+
+// Метаданные песни. Пустые значения означают, что сведения неизвестны.
+// Проверка значений выполняется сервисом
+func (sr SongRequest) meta() models.SongMeta {
+	meta := models.SongMeta{
+		Explicit: sr.Explicit,
+		ISRC:     optional(sr.ISRC),
+		Language: optional(sr.Language),
+		Key:      optional(sr.Key),
+	}
+
+	if sr.Duration != 0 {
+		meta.Duration = &sr.Duration
+	}
+
+	if sr.BPM != 0 {
+		meta.BPM = &sr.BPM
+	}
+
+	return meta
+}
+
+func optional(val string) *string {
+	if strings.TrimSpace(val) == "" {
+		return nil
+	}
+	return &val
+}
+
+func credits(names []string, role string) []models.Credit {
+	credits := make([]models.Credit, 0, len(names))
+	for _, name := range names {
+		credits = append(credits, models.Credit{Name: strings.TrimSpace(name), Role: role})
+	}
+	return credits
+}
+
+// Связывает тело запроса (json или form-data) с SongRequest и валидирует его
+func BindSong(c echo.Context) (models.SongWithDetailPlain, error) {
+	req := SongRequest{}
+
+	if !strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		if _, err := c.FormParams(); err != nil {
+			return models.SongWithDetailPlain{}, echo.NewHTTPError(400, err.Error())
+		}
+		// берем только значения из тела, без query-параметров
+		c.Request().Form = c.Request().PostForm
+	}
+
+	if err := (&echo.DefaultBinder{}).BindBody(c, &req); err != nil {
+		return models.SongWithDetailPlain{}, err
+	}
+
+	return req.ToModel()
+}
+
+// Тело запроса на частичное обновление песни.
+// Непереданные поля остаются без изменений
+type SongPatchRequest struct {
+	Group       *string `json:"group" example:"The Beatles"`
+	Song        *string `json:"song" example:"Hey Jude"`
+	ReleaseDate *string `json:"releaseDate" example:"1968-08-26"`
+	Link        *string `json:"link" example:"https://example.com/hey_jude"`
+	Text        *string `json:"text" example:"Hey Jude, dont make it bad"`
+	Duration    *int    `json:"duration" example:"431"`
+	ISRC        *string `json:"isrc" example:"GB-AYE-68-00011"`
+	Language    *string `json:"language" example:"en"`
+	Explicit    *bool   `json:"explicit"`
+	BPM         *int    `json:"bpm" example:"74"`
+	Key         *string `json:"key" example:"F"`
+}
+
+func (pr SongPatchRequest) meta() models.SongMetaPatch {
+	return models.SongMetaPatch{
+		Duration: pr.Duration,
+		ISRC:     pr.ISRC,
+		Language: pr.Language,
+		Explicit: pr.Explicit,
+		BPM:      pr.BPM,
+		Key:      pr.Key,
+	}
+}
+
+func (pr SongPatchRequest) Validate() error {
+	if pr.Group == nil && pr.Song == nil && pr.ReleaseDate == nil && pr.Link == nil && pr.Text == nil && pr.meta().IsEmpty() {
+		return echo.NewHTTPError(400, "at least one field should be provided")
+	}
+
+	for _, field := range []struct {
+		name string
+		val  *string
+	}{
+		{"group", pr.Group},
+		{"song", pr.Song},
+		{"link", pr.Link},
+		{"text", pr.Text},
+	} {
+		if field.val == nil {
+			continue
+		}
+		if strings.TrimSpace(*field.val) == "" {
+			return echo.NewHTTPError(400, fmt.Sprintf("%v should not be empty", field.name))
+		}
+		if field.name != "text" && len(*field.val) > maxStringLen {
+			return echo.NewHTTPError(400, fmt.Sprintf("%v should not be longer than %v characters", field.name, maxStringLen))
+		}
+	}
+
+	if pr.Link != nil {
+		if err := validateURL("link", *pr.Link); err != nil {
+			return err
+		}
+	}
+
+	if pr.ReleaseDate != nil {
+		if _, err := time.Parse(time.DateOnly, *pr.ReleaseDate); err != nil {
+			return echo.NewHTTPError(400, "releaseDate should be formatted as YYYY-MM-DD")
+		}
+	}
+
+	return nil
+}
+
+// Валидирует запрос и преобразует его в модель частичного обновления
+func (pr SongPatchRequest) ToModel() (models.SongPatchPlain, error) {
+	if err := pr.Validate(); err != nil {
+		return models.SongPatchPlain{}, err
+	}
+
+	patch := models.SongPatchPlain{
+		GroupName:     pr.Group,
+		SongName:      pr.Song,
+		Link:          pr.Link,
+		Text:          pr.Text,
+		SongMetaPatch: pr.meta(),
+	}
+
+	if pr.ReleaseDate != nil {
+		releaseDate, _ := time.Parse(time.DateOnly, *pr.ReleaseDate)
+		patch.ReleaseDate = &releaseDate
+	}
+
+	return patch, nil
+}
+
+// Связывает тело запроса (json или form-data) с SongPatchRequest и валидирует его.
+// Form-data разбирается вручную, так как echo не умеет заполнять nil-указатели
+func BindSongPatch(c echo.Context) (models.SongPatchPlain, error) {
+	req := SongPatchRequest{}
+
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEApplicationJSON) {
+		if err := c.Bind(&req); err != nil {
+			return models.SongPatchPlain{}, err
+		}
+		return req.ToModel()
+	}
+
+	if _, err := c.FormParams(); err != nil {
+		return models.SongPatchPlain{}, echo.NewHTTPError(400, err.Error())
+	}
+
+	// берем только значения из тела, без query-параметров
+	params := c.Request().PostForm
+	for key, field := range map[string]**string{
+		"group":       &req.Group,
+		"song":        &req.Song,
+		"releaseDate": &req.ReleaseDate,
+		"link":        &req.Link,
+		"text":        &req.Text,
+		"isrc":        &req.ISRC,
+		"language":    &req.Language,
+		"key":         &req.Key,
+	} {
+		if params.Has(key) {
+			val := params.Get(key)
+			*field = &val
+		}
+	}
+
+	for key, field := range map[string]**int{
+		"duration": &req.Duration,
+		"bpm":      &req.BPM,
+	} {
+		if params.Has(key) {
+			val, err := strconv.Atoi(params.Get(key))
+			if err != nil {
+				return models.SongPatchPlain{}, echo.NewHTTPError(400, fmt.Sprintf("%v should be an integer", key))
+			}
+			*field = &val
+		}
+	}
+
+	if params.Has("explicit") {
+		explicit, err := strconv.ParseBool(params.Get("explicit"))
+		if err != nil {
+			return models.SongPatchPlain{}, echo.NewHTTPError(400, "explicit should be a boolean")
+		}
+		req.Explicit = &explicit
+	}
+
+	return req.ToModel()
+}
+
+// Тело запроса на создание/переименование группы.
+// Принимается как в application/json, так и в form-data
+type GroupRequest struct {
+	Group string `json:"group" form:"group" example:"The Beatles"`
+}
+
+func (gr GroupRequest) Validate() error {
+	if gr.Group == "" {
+		return echo.NewHTTPError(400, "missing required fields: group")
+	}
+
+	if len(gr.Group) > maxStringLen {
+		return echo.NewHTTPError(400, fmt.Sprintf("group should not be longer than %v characters", maxStringLen))
+	}
+
+	return nil
+}
+
+// Связывает тело запроса (json или form-data) с GroupRequest, валидирует его и возвращает название группы
+func BindGroup(c echo.Context) (string, error) {
+	req := GroupRequest{}
+	if err := c.Bind(&req); err != nil {
+		return "", err
+	}
+
+	req.Group = strings.TrimSpace(req.Group)
+
+	if err := req.Validate(); err != nil {
+		return "", err
+	}
+
+	return req.Group, nil
+}
+
+// Тело запроса на создание/обновление альбома.
+// Принимается как в application/json, так и в form-data
+type AlbumRequest struct {
+	Group       string `json:"group" form:"group" example:"The Beatles"`
+	Title       string `json:"title" form:"title" example:"Abbey Road"`
+	ReleaseDate string `json:"releaseDate" form:"releaseDate" example:"1969-09-26"`
+	// LP (по умолчанию), EP, single или compilation
+	Type string `json:"type" form:"type" example:"LP"`
+}
+
+func (ar AlbumRequest) Validate() error {
+	var missing []string
+	for _, field := range []struct{ name, val string }{
+		{"group", ar.Group},
+		{"title", ar.Title},
+	} {
+		if strings.TrimSpace(field.val) == "" {
+			missing = append(missing, field.name)
+		}
+	}
+
+	if len(missing) > 0 {
+		return echo.NewHTTPError(400, fmt.Sprintf("missing required fields: %v", strings.Join(missing, ", ")))
+	}
+
+	for _, field := range []struct{ name, val string }{
+		{"group", ar.Group},
+		{"title", ar.Title},
+	} {
+		if len(field.val) > maxStringLen {
+			return echo.NewHTTPError(400, fmt.Sprintf("%v should not be longer than %v characters", field.name, maxStringLen))
+		}
+	}
+
+	if ar.ReleaseDate != "" {
+		if _, err := time.Parse(time.DateOnly, ar.ReleaseDate); err != nil {
+			return echo.NewHTTPError(400, "releaseDate should be formatted as YYYY-MM-DD")
+		}
+	}
+
+	if ar.Type != "" && !slices.Contains(models.AlbumTypes, ar.Type) {
+		return echo.NewHTTPError(400, fmt.Sprintf("type should be one of: %v", strings.Join(models.AlbumTypes, ", ")))
+	}
+
+	return nil
+}
+
+// Валидирует запрос и преобразует его в модель альбома
+func (ar AlbumRequest) ToModel() (models.Album, error) {
+	if err := ar.Validate(); err != nil {
+		return models.Album{}, err
+	}
+
+	album := models.Album{
+		GroupName: ar.Group,
+		Title:     ar.Title,
+		Type:      ar.Type,
+	}
+
+	if album.Type == "" {
+		album.Type = models.AlbumLP
+	}
+
+	if ar.ReleaseDate != "" {
+		releaseDate, _ := time.Parse(time.DateOnly, ar.ReleaseDate)
+		album.ReleaseDate = &releaseDate
+	}
+
+	return album, nil
+}
+
+// Связывает тело запроса (json или form-data) с AlbumRequest и валидирует его
+func BindAlbum(c echo.Context) (models.Album, error) {
+	req := AlbumRequest{}
+	if err := c.Bind(&req); err != nil {
+		return models.Album{}, err
+	}
+
+	return req.ToModel()
+}
+
+// Тело запроса на добавление песни в альбом
+type TrackRequest struct {
+	// номер диска, по умолчанию 1
+	Disc  int `json:"disc" form:"disc" example:"1"`
+	Track int `json:"track" form:"track" example:"3"`
+}
+
+func (tr TrackRequest) Validate() error {
+	if tr.Track <= 0 {
+		return echo.NewHTTPError(400, "track should be a positive number")
+	}
+
+	if tr.Disc < 0 {
+		return echo.NewHTTPError(400, "disc should be a positive number")
+	}
+
+	return nil
+}
+
+// Связывает тело запроса (json или form-data) с TrackRequest и валидирует его
+func BindTrack(c echo.Context) (models.TrackPosition, error) {
+	req := TrackRequest{}
+	if err := c.Bind(&req); err != nil {
+		return models.TrackPosition{}, err
+	}
+
+	if err := req.Validate(); err != nil {
+		return models.TrackPosition{}, err
+	}
+
+	pos := models.TrackPosition{
+		DiscNum:  req.Disc,
+		TrackNum: req.Track,
+	}
+
+	if pos.DiscNum == 0 {
+		pos.DiscNum = 1
+	}
+
+	return pos, nil
+}
+
+// Тело запроса на добавление участника песни
+type CreditRequest struct {
+	Person string `json:"person" form:"person" example:"Paul McCartney"`
+	// lyricist, composer или producer
+	Role string `json:"role" form:"role" example:"lyricist"`
+}
+
+func (cr CreditRequest) Validate() error {
+	if strings.TrimSpace(cr.Person) == "" || cr.Role == "" {
+		return echo.NewHTTPError(400, "person and role should be provided")
+	}
+
+	if len(cr.Person) > maxStringLen {
+		return echo.NewHTTPError(400, fmt.Sprintf("person should not be longer than %v characters", maxStringLen))
+	}
+
+	if !slices.Contains(models.CreditRoles, cr.Role) {
+		return echo.NewHTTPError(400, fmt.Sprintf("role should be one of: %v", strings.Join(models.CreditRoles, ", ")))
+	}
+
+	return nil
+}
+
+// Связывает тело запроса (json или form-data) с CreditRequest и валидирует его
+func BindCredit(c echo.Context) (CreditRequest, error) {
+	req := CreditRequest{}
+	if err := c.Bind(&req); err != nil {
+		return CreditRequest{}, err
+	}
+
+	req.Person = strings.TrimSpace(req.Person)
+	req.Role = strings.ToLower(req.Role)
+
+	if err := req.Validate(); err != nil {
+		return CreditRequest{}, err
+	}
+
+	return req, nil
+}
+
+// Тело запроса на замену тегов песни
+type TagsRequest struct {
+	Tags []string `json:"tags" form:"tags"`
+}
+
+// Связывает тело запроса (json или form-data) с TagsRequest и нормализует теги
+func BindTags(c echo.Context) ([]string, error) {
+	req := TagsRequest{}
+	if err := c.Bind(&req); err != nil {
+		return nil, err
+	}
+
+	return NormalizeNames(req.Tags)
+}
+
+// Тело запроса на замену жанров песни
+type GenresRequest struct {
+	Genres []string `json:"genres" form:"genres"`
+}
+
+// Связывает тело запроса (json или form-data) с GenresRequest и нормализует жанры
+func BindGenres(c echo.Context) ([]string, error) {
+	req := GenresRequest{}
+	if err := c.Bind(&req); err != nil {
+		return nil, err
+	}
+
+	return NormalizeNames(req.Genres)
+}
+
+// код страны ISO 3166-1
+var regionRe = regexp.MustCompile(`^[A-Z]{2}$`)
+
+// Тело запроса на добавление ссылки на песню
+type LinkRequest struct {
+	URL string `json:"url" form:"url" example:"https://www.youtube.com/watch?v=A_MjCqQoLLA"`
+	// primary, youtube, streaming, lyrics или purchase
+	Type string `json:"type" form:"type" example:"youtube"`
+	// код страны ISO 3166-1, пустое значение - ссылка доступна везде
+	Region string `json:"region" form:"region" example:"GB"`
+}
+
+func (lr LinkRequest) Validate() error {
+	if lr.URL == "" || lr.Type == "" {
+		return echo.NewHTTPError(400, "url and type should be provided")
+	}
+
+	if len(lr.URL) > maxStringLen {
+		return echo.NewHTTPError(400, fmt.Sprintf("url should not be longer than %v characters", maxStringLen))
+	}
+
+	if err := validateURL("url", lr.URL); err != nil {
+		return err
+	}
+
+	if !slices.Contains(models.LinkTypes, lr.Type) {
+		return echo.NewHTTPError(400, fmt.Sprintf("type should be one of: %v", strings.Join(models.LinkTypes, ", ")))
+	}
+
+	if lr.Region != "" && !regionRe.MatchString(lr.Region) {
+		return echo.NewHTTPError(400, "region should be a two-letter ISO 3166-1 country code")
+	}
+
+	return nil
+}
+
+// Проверяет, что ссылка - абсолютный http(s) url
+func validateURL(name, val string) error {
+	if parsed, err := url.Parse(val); err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return echo.NewHTTPError(400, fmt.Sprintf("%v should be an absolute http(s) url", name))
+	}
+	return nil
+}
+
+// Связывает тело запроса (json или form-data) с LinkRequest и преобразует его в модель ссылки
+func BindLink(c echo.Context) (models.SongLink, error) {
+	req := LinkRequest{}
+	if err := c.Bind(&req); err != nil {
+		return models.SongLink{}, err
+	}
+
+	req.URL = strings.TrimSpace(req.URL)
+	req.Type = strings.ToLower(req.Type)
+	req.Region = strings.ToUpper(strings.TrimSpace(req.Region))
+
+	if err := req.Validate(); err != nil {
+		return models.SongLink{}, err
+	}
+
+	return models.SongLink{
+		URL:    req.URL,
+		Type:   req.Type,
+		Region: optional(req.Region),
+	}, nil
+}
@@ -0,0 +1,39 @@
+package common
+
+import (
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/cutlery47/music-storage/internal/service"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// Отправляет страницу текста песни, общую для всех версий API.
+// Ссылки на соседние страницы передаются в заголовке Link,
+// если клиент предпочитает text/plain - отвечает обычным текстом
+func WriteText(c echo.Context, srv service.Service, e *ErrMapper, id uuid.UUID, query TextQuery) error {
+	ctx := c.Request().Context()
+
+	var text models.SongText
+	var err error
+
+	if query.Cursor != nil {
+		text, err = srv.GetTextByCursor(ctx, query.Limit, *query.Cursor, id, query.Filter)
+	} else {
+		text, err = srv.GetText(ctx, query.Limit, query.Offset, id, query.Filter)
+	}
+	if err != nil {
+		return e.Map(err)
+	}
+
+	page := OffsetPage(text.Verses, text.Total, query.Limit, query.Offset)
+	if query.Cursor != nil {
+		page = CursorPage(text.Verses, text.Total, query.Limit, text.NextCursor, text.PrevCursor)
+	}
+	page.SetLinks(c)
+
+	if PrefersPlainText(c) {
+		return c.String(200, text.Plain(query.Labels))
+	}
+
+	return c.JSON(200, page)
+}
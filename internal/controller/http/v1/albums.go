@@ -0,0 +1,263 @@
+package v1
+
+import (
+	"github.com/cutlery47/music-storage/internal/controller/http/common"
+	"github.com/cutlery47/music-storage/internal/service"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type albumRoutes struct {
+	srv service.Service
+	e   *common.ErrMapper
+}
+
+func newAlbumRoutes(g *echo.Group, srv service.Service, e *common.ErrMapper) {
+	r := &albumRoutes{
+		srv: srv,
+		e:   e,
+	}
+
+	g.POST("", r.createAlbum)
+	g.GET("", r.getAlbums)
+	g.GET("/:id", r.getAlbum)
+	g.GET("/:id/tracks", r.getAlbumTracks)
+	g.PUT("/:id", r.updateAlbum)
+	g.DELETE("/:id", r.deleteAlbum)
+	g.PUT("/:id/tracks/:songId", r.setAlbumTrack)
+	g.DELETE("/:id/tracks/:songId", r.removeAlbumTrack)
+}
+
+// @Summary 		Create Album
+// @Description 	Create a new album. The group is created if it doesn't exist yet
+// @Tags 			Albums
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			album		body		common.AlbumRequest		true    "album data"
+// @Success			200 		{object} 	models.Album
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/albums [post]
+func (r *albumRoutes) createAlbum(c echo.Context) error {
+	album, err := common.BindAlbum(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	album, err = r.srv.CreateAlbum(ctx, album)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, album)
+}
+
+// @Summary 		Get Albums
+// @Description 	Get a page of albums ordered by group and release date
+// @Tags 			Albums
+// @Param			group		query		string		false   "group id"
+// @Param			limit		query		int			true    "pagination limit"
+// @Param 			offset		query		int			true	"pagination offset"
+// @Success			200 		{object} 	[]models.Album
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/albums [get]
+func (r *albumRoutes) getAlbums(c echo.Context) error {
+	params := c.QueryParams()
+
+	var groupId *uuid.UUID
+	if params.Has("group") {
+		id, err := uuid.Parse(params.Get("group"))
+		if err != nil {
+			return ErrBadQuery
+		}
+		groupId = &id
+	}
+
+	limit, err := common.ParseLimit(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	offset, err := common.ParseOffset(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	ctx := c.Request().Context()
+	albums, err := r.srv.GetAlbums(ctx, limit, offset, groupId)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, albums)
+}
+
+// @Summary 		Get Album
+// @Description 	Get info about a particular album
+// @Tags 			Albums
+// @Param			id			path		string		true    "album id"
+// @Success			200 		{object} 	models.AlbumWithDetail
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/albums/{id} [get]
+func (r *albumRoutes) getAlbum(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	ctx := c.Request().Context()
+	album, err := r.srv.GetAlbum(ctx, id)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, album)
+}
+
+// @Summary 		Get Album Tracks
+// @Description 	Get the tracklist of a particular album ordered by disc and track number
+// @Tags 			Albums
+// @Param			id			path		string		true    "album id"
+// @Success			200 		{object} 	[]models.Track
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/albums/{id}/tracks [get]
+func (r *albumRoutes) getAlbumTracks(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	ctx := c.Request().Context()
+	tracks, err := r.srv.GetAlbumTracks(ctx, id)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, tracks)
+}
+
+// @Summary 		Update Album
+// @Description 	Replace album data
+// @Tags 			Albums
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			id			path		string					true    "album id"
+// @Param			album		body		common.AlbumRequest		true    "edited album data"
+// @Success			200 		{object} 	string
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/albums/{id} [put]
+func (r *albumRoutes) updateAlbum(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	album, err := common.BindAlbum(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	if err := r.srv.UpdateAlbum(ctx, id, album); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
+
+// @Summary 		Delete Album
+// @Description 	Delete an album. The album's songs are kept
+// @Tags 			Albums
+// @Param			id			path		string		true    "album id"
+// @Success			200 		{object} 	string
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/albums/{id} [delete]
+func (r *albumRoutes) deleteAlbum(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	ctx := c.Request().Context()
+	if err := r.srv.DeleteAlbum(ctx, id); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
+
+// @Summary 		Set Album Track
+// @Description 	Add a song to the album at the given position, or move it if it's already on the album
+// @Tags 			Albums
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			id			path		string					true    "album id"
+// @Param			songId		path		string					true    "song id"
+// @Param			track		body		common.TrackRequest		true    "track position"
+// @Success			200 		{object} 	string
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/albums/{id}/tracks/{songId} [put]
+func (r *albumRoutes) setAlbumTrack(c echo.Context) error {
+	albumId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	songId, err := uuid.Parse(c.Param("songId"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	pos, err := common.BindTrack(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	if err := r.srv.SetAlbumTrack(ctx, albumId, songId, pos); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
+
+// @Summary 		Remove Album Track
+// @Description 	Remove a song from the album. The song itself is kept
+// @Tags 			Albums
+// @Param			id			path		string		true    "album id"
+// @Param			songId		path		string		true    "song id"
+// @Success			200 		{object} 	string
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/albums/{id}/tracks/{songId} [delete]
+func (r *albumRoutes) removeAlbumTrack(c echo.Context) error {
+	albumId, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	songId, err := uuid.Parse(c.Param("songId"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	ctx := c.Request().Context()
+	if err := r.srv.RemoveAlbumTrack(ctx, albumId, songId); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
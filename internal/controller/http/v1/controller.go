@@ -2,24 +2,59 @@ package v1
 
 import (
 	_ "github.com/cutlery47/music-storage/docs"
+	"github.com/cutlery47/music-storage/internal/controller/http/common"
 	"github.com/cutlery47/music-storage/internal/service"
 	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
 	"github.com/sirupsen/logrus"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
+// Регистрирует маршруты /api/v1 и общие эндпойнты (healthcheck, swagger).
+// Общие middleware подключаются в app
 func NewController(e *echo.Echo, srv service.Service, infoLog, errLog *logrus.Logger) {
-	e.Use(middleware.Recover())
-
 	// healthcheck endpoing
 	e.GET("/ping", func(c echo.Context) error { return c.NoContent(200) })
 	// swagger endpoint
 	e.GET("/swagger/*", echoSwagger.WrapHandler)
 
-	v1 := e.Group("/api/v1/songs", requestLoggerMiddleware(infoLog))
+	v1Health := e.Group("/health")
+	{
+		newHealthRoutes(v1Health, srv)
+	}
+
+	v1 := e.Group("/api/v1/songs", common.RequestLoggerMiddleware(infoLog))
+	{
+		newSongRoutes(v1, srv, common.NewErrMapper(errLog))
+	}
+
+	v1Groups := e.Group("/api/v1/groups", common.RequestLoggerMiddleware(infoLog))
+	{
+		newGroupRoutes(v1Groups, srv, common.NewErrMapper(errLog))
+	}
+
+	v1Albums := e.Group("/api/v1/albums", common.RequestLoggerMiddleware(infoLog))
+	{
+		newAlbumRoutes(v1Albums, srv, common.NewErrMapper(errLog))
+	}
+
+	v1People := e.Group("/api/v1/people", common.RequestLoggerMiddleware(infoLog))
+	{
+		newPersonRoutes(v1People, srv, common.NewErrMapper(errLog))
+	}
+
+	v1Genres := e.Group("/api/v1/genres", common.RequestLoggerMiddleware(infoLog))
+	{
+		newGenreRoutes(v1Genres, srv, common.NewErrMapper(errLog))
+	}
+
+	v1Tags := e.Group("/api/v1/tags", common.RequestLoggerMiddleware(infoLog))
+	{
+		newTagRoutes(v1Tags, srv, common.NewErrMapper(errLog))
+	}
+
+	v1Links := e.Group("/api/v1/links", common.RequestLoggerMiddleware(infoLog))
 	{
-		newSongRoutes(v1, srv, newErrMapper(errLog))
+		newLinkRoutes(v1Links, srv, common.NewErrMapper(errLog))
 	}
 
 }
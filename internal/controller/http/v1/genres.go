@@ -0,0 +1,104 @@
+package v1
+
+import (
+	"github.com/cutlery47/music-storage/internal/controller/http/common"
+	"github.com/cutlery47/music-storage/internal/service"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type genreRoutes struct {
+	srv service.Service
+	e   *common.ErrMapper
+}
+
+func newGenreRoutes(g *echo.Group, srv service.Service, e *common.ErrMapper) {
+	r := &genreRoutes{
+		srv: srv,
+		e:   e,
+	}
+
+	g.POST("", r.createGenre)
+	g.GET("", r.getGenres)
+	g.DELETE("/:id", r.deleteGenre)
+}
+
+// @Summary 		Create Genre
+// @Description 	Create a new genre, optionally as a subgenre of an existing one. Names are stored in lowercase
+// @Tags 			Genres
+// @Param			name		formData	string		true    "genre name"
+// @Param			parent		formData	string		false   "parent genre name"
+// @Success			200 		{object} 	models.Genre
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/genres [post]
+func (r *genreRoutes) createGenre(c echo.Context) error {
+	params, _ := c.FormParams()
+
+	names, err := common.NormalizeNames([]string{params.Get("name")})
+	if err != nil {
+		return err
+	}
+
+	if len(names) == 0 {
+		return ErrBadBody
+	}
+
+	var parent *string
+	if params.Has("parent") {
+		parents, err := common.NormalizeNames([]string{params.Get("parent")})
+		if err != nil {
+			return err
+		}
+		if len(parents) > 0 {
+			parent = &parents[0]
+		}
+	}
+
+	ctx := c.Request().Context()
+	genre, err := r.srv.CreateGenre(ctx, names[0], parent)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, genre)
+}
+
+// @Summary 		Get Genres
+// @Description 	Get all genres ordered by name, along with their parent genres
+// @Tags 			Genres
+// @Success			200 		{object} 	[]models.Genre
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/genres [get]
+func (r *genreRoutes) getGenres(c echo.Context) error {
+	ctx := c.Request().Context()
+	genres, err := r.srv.GetGenres(ctx)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, genres)
+}
+
+// @Summary 		Delete Genre
+// @Description 	Delete a genre. Its subgenres become top-level genres
+// @Tags 			Genres
+// @Param			id			path		string		true    "genre id"
+// @Success			200 		{object} 	string
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/genres/{id} [delete]
+func (r *genreRoutes) deleteGenre(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	ctx := c.Request().Context()
+	if err := r.srv.DeleteGenre(ctx, id); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
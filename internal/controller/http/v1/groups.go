@@ -0,0 +1,200 @@
+package v1
+
+import (
+	"github.com/cutlery47/music-storage/internal/controller/http/common"
+	"github.com/cutlery47/music-storage/internal/service"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type groupRoutes struct {
+	srv service.Service
+	e   *common.ErrMapper
+}
+
+func newGroupRoutes(g *echo.Group, srv service.Service, e *common.ErrMapper) {
+	r := &groupRoutes{
+		srv: srv,
+		e:   e,
+	}
+
+	g.POST("", r.createGroup)
+	g.GET("", r.getGroups)
+	g.GET("/:id", r.getGroup)
+	g.GET("/:id/songs", r.getGroupSongs)
+	g.PUT("/:id", r.renameGroup)
+	g.DELETE("/:id", r.deleteGroup)
+}
+
+// @Summary 		Create Group
+// @Description 	Create a new group
+// @Tags 			Groups
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			group		body		common.GroupRequest		true    "group data"
+// @Success			200 		{object} 	models.Group
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/groups [post]
+func (r *groupRoutes) createGroup(c echo.Context) error {
+	groupName, err := common.BindGroup(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	group, err := r.srv.CreateGroup(ctx, groupName)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, group)
+}
+
+// @Summary 		Get Groups
+// @Description 	Get a page of groups ordered by name
+// @Tags 			Groups
+// @Param			limit		query		int			true    "pagination limit"
+// @Param 			offset		query		int			true	"pagination offset"
+// @Success			200 		{object} 	[]models.Group
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/groups [get]
+func (r *groupRoutes) getGroups(c echo.Context) error {
+	params := c.QueryParams()
+
+	limit, err := common.ParseLimit(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	offset, err := common.ParseOffset(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	ctx := c.Request().Context()
+	groups, err := r.srv.GetGroups(ctx, limit, offset)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, groups)
+}
+
+// @Summary 		Get Group
+// @Description 	Get info about a particular group
+// @Tags 			Groups
+// @Param			id			path		string		true    "group id"
+// @Success			200 		{object} 	models.GroupWithDetail
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/groups/{id} [get]
+func (r *groupRoutes) getGroup(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	ctx := c.Request().Context()
+	group, err := r.srv.GetGroup(ctx, id)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, group)
+}
+
+// @Summary 		Get Group Songs
+// @Description 	Get songs of a particular group
+// @Tags 			Groups
+// @Param			id			path		string		true    "group id"
+// @Param			limit		query		int			true    "pagination limit"
+// @Param 			offset		query		int			true	"pagination offset"
+// @Success			200 		{object} 	[]models.SongWithDetail
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/groups/{id}/songs [get]
+func (r *groupRoutes) getGroupSongs(c echo.Context) error {
+	params := c.QueryParams()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	limit, err := common.ParseLimit(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	offset, err := common.ParseOffset(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	ctx := c.Request().Context()
+	songs, err := r.srv.GetGroupSongs(ctx, id, limit, offset)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, songs)
+}
+
+// @Summary 		Rename Group
+// @Description 	Rename a group. All of the group's songs are renamed at once
+// @Tags 			Groups
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			id			path		string		true    "group id"
+// @Param			group		body		common.GroupRequest		true    "new group data"
+// @Success			200 		{object} 	string
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/groups/{id} [put]
+func (r *groupRoutes) renameGroup(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	groupName, err := common.BindGroup(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	if err := r.srv.RenameGroup(ctx, id, groupName); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
+
+// @Summary 		Delete Group
+// @Description 	Delete a group. A group that still has songs (including songs in the trash and songs featuring the group) can't be deleted: delete the songs first and wait for the trash to be purged
+// @Tags 			Groups
+// @Param			id			path		string		true    "group id"
+// @Success			200 		{object} 	string
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			409			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/groups/{id} [delete]
+func (r *groupRoutes) deleteGroup(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	ctx := c.Request().Context()
+	if err := r.srv.DeleteGroup(ctx, id); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
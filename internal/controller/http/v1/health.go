@@ -0,0 +1,27 @@
+package v1
+
+import (
+	"github.com/cutlery47/music-storage/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type healthRoutes struct {
+	srv service.Service
+}
+
+func newHealthRoutes(g *echo.Group, srv service.Service) {
+	r := &healthRoutes{
+		srv: srv,
+	}
+
+	g.GET("/outbound", r.getOutbound)
+}
+
+// @Summary 		Get Outbound Stats
+// @Description 	Get statistics of outbound requests to external services by service name: request and failure counts, cache hits, circuit breaker and bulkhead rejections, latency (in nanoseconds), circuit breaker state, in-flight requests and cache size
+// @Tags 			Health
+// @Success			200 		{object} 	map[string]httpclient.Stats
+// @Router 			/health/outbound [get]
+func (r *healthRoutes) getOutbound(c echo.Context) error {
+	return c.JSON(200, r.srv.GetOutboundStats(c.Request().Context()))
+}
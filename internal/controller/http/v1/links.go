@@ -0,0 +1,52 @@
+package v1
+
+import (
+	"github.com/cutlery47/music-storage/internal/controller/http/common"
+	"github.com/cutlery47/music-storage/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type linkRoutes struct {
+	srv service.Service
+	e   *common.ErrMapper
+}
+
+func newLinkRoutes(g *echo.Group, srv service.Service, e *common.ErrMapper) {
+	r := &linkRoutes{
+		srv: srv,
+		e:   e,
+	}
+
+	g.GET("/broken", r.getBrokenLinks)
+}
+
+// @Summary 		Get Broken Links
+// @Description 	Get song links that failed their last background check (error response or no response at all), most recently checked first
+// @Tags 			Links
+// @Param			limit		query		int			true    "pagination limit"
+// @Param 			offset		query		int			true	"pagination offset"
+// @Success			200 		{object} 	[]models.BrokenLink
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/links/broken [get]
+func (r *linkRoutes) getBrokenLinks(c echo.Context) error {
+	params := c.QueryParams()
+
+	limit, err := common.ParseLimit(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	offset, err := common.ParseOffset(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	ctx := c.Request().Context()
+	links, err := r.srv.GetBrokenLinks(ctx, limit, offset)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, links)
+}
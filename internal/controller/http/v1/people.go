@@ -0,0 +1,142 @@
+package v1
+
+import (
+	"github.com/cutlery47/music-storage/internal/controller/http/common"
+	"github.com/cutlery47/music-storage/internal/service"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type personRoutes struct {
+	srv service.Service
+	e   *common.ErrMapper
+}
+
+func newPersonRoutes(g *echo.Group, srv service.Service, e *common.ErrMapper) {
+	r := &personRoutes{
+		srv: srv,
+		e:   e,
+	}
+
+	g.POST("", r.createPerson)
+	g.GET("", r.getPeople)
+	g.GET("/:id", r.getPerson)
+	g.GET("/:id/songs", r.getPersonSongs)
+}
+
+// @Summary 		Create Person
+// @Description 	Create a new songwriter or producer
+// @Tags 			People
+// @Param			name		formData	string		true    "full name"
+// @Success			200 		{object} 	models.Person
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/people [post]
+func (r *personRoutes) createPerson(c echo.Context) error {
+	params, _ := c.FormParams()
+
+	if !params.Has("name") {
+		return ErrBadBody
+	}
+
+	ctx := c.Request().Context()
+	person, err := r.srv.CreatePerson(ctx, params.Get("name"))
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, person)
+}
+
+// @Summary 		Get People
+// @Description 	Get a page of songwriters and producers ordered by name
+// @Tags 			People
+// @Param			limit		query		int			true    "pagination limit"
+// @Param 			offset		query		int			true	"pagination offset"
+// @Success			200 		{object} 	[]models.Person
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/people [get]
+func (r *personRoutes) getPeople(c echo.Context) error {
+	params := c.QueryParams()
+
+	limit, err := common.ParseLimit(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	offset, err := common.ParseOffset(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	ctx := c.Request().Context()
+	people, err := r.srv.GetPeople(ctx, limit, offset)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, people)
+}
+
+// @Summary 		Get Person
+// @Description 	Get info about a particular songwriter or producer
+// @Tags 			People
+// @Param			id			path		string		true    "person id"
+// @Success			200 		{object} 	models.Person
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/people/{id} [get]
+func (r *personRoutes) getPerson(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	ctx := c.Request().Context()
+	person, err := r.srv.GetPerson(ctx, id)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, person)
+}
+
+// @Summary 		Get Person Songs
+// @Description 	Get songs a particular person is credited on, along with their roles
+// @Tags 			People
+// @Param			id			path		string		true    "person id"
+// @Param			limit		query		int			true    "pagination limit"
+// @Param 			offset		query		int			true	"pagination offset"
+// @Success			200 		{object} 	[]models.PersonSong
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v1/people/{id}/songs [get]
+func (r *personRoutes) getPersonSongs(c echo.Context) error {
+	params := c.QueryParams()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	limit, err := common.ParseLimit(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	offset, err := common.ParseOffset(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	ctx := c.Request().Context()
+	songs, err := r.srv.GetPersonSongs(ctx, id, limit, offset)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, songs)
+}
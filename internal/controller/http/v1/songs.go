@@ -2,20 +2,25 @@ package v1
 
 import (
 	"fmt"
+	"net/url"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/cutlery47/music-storage/internal/controller/http/common"
 	"github.com/cutlery47/music-storage/internal/models"
 	"github.com/cutlery47/music-storage/internal/service"
+	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
 
 type songRoutes struct {
 	srv service.Service
-	e   *errMapper
+	e   *common.ErrMapper
 }
 
-func newSongRoutes(g *echo.Group, srv service.Service, e *errMapper) {
+func newSongRoutes(g *echo.Group, srv service.Service, e *common.ErrMapper) {
 	r := &songRoutes{
 		srv: srv,
 		e:   e,
@@ -25,16 +30,23 @@ func newSongRoutes(g *echo.Group, srv service.Service, e *errMapper) {
 	g.GET("", r.getSongs)
 	g.GET("/info", r.getInfo)
 	g.GET("/text", r.getText)
+	g.GET("/search", r.searchSongs)
 	g.DELETE("", r.deleteSong)
 	g.PUT("", r.updateSong)
+	g.PATCH("", r.patchSong)
+	g.POST("/credits", r.addCredit)
+	g.DELETE("/credits", r.removeCredit)
+	g.GET("/links", r.getLinks)
+	g.POST("/links", r.addLink)
+	g.DELETE("/links", r.removeLink)
 }
 
 // @Summary 		Get Info
-// @Description 	Get info about a particular song
+// @Description 	Get info about a particular song, including its songwriters and producers
 // @Tags 			Songs
 // @Param			group		query		string		true    "desired group"
 // @Param 			song		query		string		true	"desired song"
-// @Success			200 		{object} 	models.SongDetail
+// @Success			200 		{object} 	models.SongDetailWithCredits
 // @Failure 		400			{object}    echo.HTTPError
 // @Failure			404			{object}	echo.HTTPError
 // @Failure			500			{object} 	echo.HTTPError
@@ -52,9 +64,13 @@ func (r *songRoutes) getInfo(c echo.Context) error {
 	}
 
 	ctx := c.Request().Context()
-	detail, err := r.srv.GetDetail(ctx, song)
+	id, err := r.srv.GetId(ctx, song)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	detail, err := r.srv.GetDetail(ctx, id)
 	if err != nil {
-		fmt.Println(err)
 		return r.e.Map(err)
 	}
 
@@ -62,15 +78,33 @@ func (r *songRoutes) getInfo(c echo.Context) error {
 }
 
 // @Summary 		Get Songs
-// @Description 	Get songs by specified filters
+// @Description 	Get songs by specified filters. Responds with a page of songs with pagination metadata; links to neighbouring pages are also sent in the Link header. If the cursor parameter is present (empty for the first page), keyset pagination is used: next/prev cursors are returned instead of offsets and offset is ignored
 // @Tags 			Songs
 // @Param			group				query		string		false   "desired group"
 // @Param 			song				query		string		false   "desired song"
+// @Param 			artist				query		string		false   "any credited artist: primary, featured or remixer"
 // @Param			releasedBefore		query		string		false   "upper time-bound for when the song was released"
 // @Param 			releasedAfter		query		string		false	"lower time-bound for when the song was released"
+// @Param			match				query		string		false	"how group, song and artist are matched: exact (default), icase, prefix or fuzzy (trigram similarity, ordered by similarity)"
+// @Param			tags				query		string		false	"comma-separated tags the song should have"
+// @Param			excludeTags			query		string		false	"comma-separated tags the song should not have"
+// @Param			tagMatch			query		string		false	"how tags are matched: all (default) or any"
+// @Param			genres				query		string		false	"comma-separated genres the song should belong to, subgenres included"
+// @Param			excludeGenres		query		string		false	"comma-separated genres the song should not belong to, subgenres included"
+// @Param			genreMatch			query		string		false	"how genres are matched: all (default) or any"
+// @Param			minDuration			query		int			false	"lower bound for song duration in seconds"
+// @Param			maxDuration			query		int			false	"upper bound for song duration in seconds"
+// @Param			minBpm				query		int			false	"lower bound for song tempo"
+// @Param			maxBpm				query		int			false	"upper bound for song tempo"
+// @Param			language			query		string		false	"lyrics language, ISO 639-1 code"
+// @Param			explicit			query		bool		false	"whether the song has explicit lyrics"
+// @Param			key					query		string		false	"musical key, e.g. C, F#, Bbm or A minor"
+// @Param			isrc				query		string		false	"ISRC code, hyphens are optional"
+// @Param			sort				query		string		false	"comma-separated sort keys: group, song, releaseDate, createdAt; prefix with - for descending order, e.g. -releaseDate,song. Defaults to group,song"
 // @Param			limit				query		int			true    "pagination limit"
-// @Param 			offset				query		int			true	"pagination offset"
-// @Success			200 				{object} 	[]models.SongWithDetail
+// @Param 			offset				query		int			false	"pagination offset (required without cursor)"
+// @Param 			cursor				query		string		false	"pagination cursor from a previous page; empty for the first page"
+// @Success			200 				{object} 	common.PageMeta{items=[]models.SongWithDetail}
 // @Failure 		400					{object}    echo.HTTPError
 // @Failure			404					{object}	echo.HTTPError
 // @Failure			500					{object} 	echo.HTTPError
@@ -78,69 +112,61 @@ func (r *songRoutes) getInfo(c echo.Context) error {
 func (r *songRoutes) getSongs(c echo.Context) error {
 	params := c.QueryParams()
 
-	var group, song *string
-	var releasedBefore, releasedAfter *time.Time
-
-	if params.Has("group") {
-		param := params.Get("group")
-		group = &param
+	filter, err := parseFilter(params)
+	if err != nil {
+		return err
 	}
 
-	if params.Has("song") {
-		param := params.Get("song")
-		song = &param
+	limit, err := common.ParseLimit(params)
+	if err != nil {
+		return ErrBadQueryPagination
 	}
 
-	if params.Has("releasedBefore") {
-		parsedBefore, err := time.Parse(time.DateOnly, params.Get("releasedBefore"))
-		if err != nil {
-			return ErrBadQueryTime
-		}
-		releasedBefore = &parsedBefore
-	}
+	ctx := c.Request().Context()
 
-	if params.Has("releasedAfter") {
-		parsedAfter, err := time.Parse(time.DateOnly, params.Get("releasedAfter"))
+	// пагинация по курсору; пустой курсор - первая страница
+	if params.Has("cursor") {
+		songs, err := r.srv.GetSongsByCursor(ctx, limit, params.Get("cursor"), filter)
 		if err != nil {
-			return ErrBadQueryTime
+			return r.e.Map(err)
 		}
-		releasedAfter = &parsedAfter
-	}
 
-	filter := models.Filter{
-		Group:          group,
-		Song:           song,
-		ReleasedBefore: releasedBefore,
-		ReleasedAfter:  releasedAfter,
-	}
+		page := common.CursorPage(songs.Songs, songs.Total, limit, songs.NextCursor, songs.PrevCursor)
+		page.SetLinks(c)
 
-	limit, err := strconv.Atoi(params.Get("limit"))
-	if err != nil {
-		return ErrBadQueryPagination
+		return c.JSON(200, page)
 	}
 
-	offset, err := strconv.Atoi(params.Get("offset"))
+	offset, err := common.ParseOffset(params)
 	if err != nil {
 		return ErrBadQueryPagination
 	}
 
-	ctx := c.Request().Context()
 	songs, err := r.srv.GetSongs(ctx, limit, offset, filter)
 	if err != nil {
 		return r.e.Map(err)
 	}
 
-	return c.JSON(200, songs)
+	page := common.OffsetPage(songs.Songs, songs.Total, limit, offset)
+	page.SetLinks(c)
+
+	return c.JSON(200, page)
 }
 
 // @Summary 		Get Texts
-// @Description 	Get specified songs' lyrics
+// @Description 	Get specified songs' lyrics page by verses with pagination metadata; links to neighbouring pages are also sent in the Link header. Responds with plain text if text/plain is preferred in the Accept header
 // @Tags 			Songs
+// @Produce			json
+// @Produce			plain
 // @Param			group				query		string		true   "desired group"
 // @Param 			song				query		string		true   "desired song"
 // @Param			limit				query		int			true    "pagination limit"
-// @Param 			offset				query		int			true	"pagination offset"
-// @Success			200 				{object} 	string
+// @Param 			offset				query		int			false	"pagination offset (required without cursor)"
+// @Param 			cursor				query		string		false	"pagination cursor from a previous page; empty for the first page"
+// @Param			section				query		string		false	"return only verses of this section type (intro, verse, pre-chorus, chorus, bridge, hook, outro, other)"
+// @Param			collapse			query		bool		false	"skip repeated verses (e.g. repeated choruses)"
+// @Param			labels				query		bool		false	"prefix verses with section labels, e.g. [Chorus] (text/plain only)"
+// @Success			200 				{object} 	common.PageMeta{items=[]models.Verse}
 // @Failure 		400					{object}    echo.HTTPError
 // @Failure			404					{object}	echo.HTTPError
 // @Failure			500					{object} 	echo.HTTPError
@@ -148,7 +174,7 @@ func (r *songRoutes) getSongs(c echo.Context) error {
 func (r *songRoutes) getText(c echo.Context) error {
 	params := c.QueryParams()
 
-	if !params.Has("song") || !params.Has("group") || !params.Has("limit") || !params.Has("offset") {
+	if !params.Has("song") || !params.Has("group") || !params.Has("limit") || (!params.Has("offset") && !params.Has("cursor")) {
 		return ErrBadQuery
 	}
 
@@ -157,29 +183,62 @@ func (r *songRoutes) getText(c echo.Context) error {
 		SongName:  params.Get("song"),
 	}
 
-	var limit, offset int
+	query, err := common.ParseTextQuery(params)
+	if err != nil {
+		return err
+	}
 
-	limit, err := strconv.Atoi(params.Get("limit"))
+	ctx := c.Request().Context()
+	id, err := r.srv.GetId(ctx, song)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return common.WriteText(c, r.srv, r.e, id, query)
+}
+
+// @Summary 		Search Songs
+// @Description 	Full-text search over lyrics. Returns a page of songs ranked by relevance together with the total number of matching songs, each with its best matching verse highlighted with <mark> tags
+// @Tags 			Songs
+// @Param			q					query		string		true    "search query (supports quotes, OR and -exclusion)"
+// @Param			lang				query		string		false   "text search language: english (en), russian (ru) or simple. Defaults to the configured language"
+// @Param			limit				query		int			true    "pagination limit"
+// @Param 			offset				query		int			true	"pagination offset"
+// @Success			200 				{object} 	common.PageMeta{items=[]models.SearchResult}
+// @Failure 		400					{object}    echo.HTTPError
+// @Failure			500					{object} 	echo.HTTPError
+// @Router 			/api/v1/songs/search [get]
+func (r *songRoutes) searchSongs(c echo.Context) error {
+	params := c.QueryParams()
+
+	if strings.TrimSpace(params.Get("q")) == "" {
+		return ErrBadQuery
+	}
+
+	limit, err := common.ParseLimit(params)
 	if err != nil {
 		return ErrBadQueryPagination
 	}
 
-	offset, err = strconv.Atoi(params.Get("offset"))
+	offset, err := common.ParseOffset(params)
 	if err != nil {
 		return ErrBadQueryPagination
 	}
 
 	ctx := c.Request().Context()
-	text, err := r.srv.GetText(ctx, limit, offset, song)
+	results, err := r.srv.Search(ctx, limit, offset, params.Get("q"), params.Get("lang"))
 	if err != nil {
 		return r.e.Map(err)
 	}
 
-	return c.JSON(200, text)
+	page := common.OffsetPage(results.Results, results.Total, limit, offset)
+	page.SetLinks(c)
+
+	return c.JSON(200, page)
 }
 
 // @Summary 		Delete Song
-// @Description 	Delete specific song
+// @Description 	Move specific song to the trash. It can be restored via /api/v2/trash until it is permanently removed after the retention period
 // @Tags 			Songs
 // @Param			group				query		string		true   "desired group"
 // @Param 			song				query		string		true   "desired song"
@@ -201,7 +260,12 @@ func (r *songRoutes) deleteSong(c echo.Context) error {
 	}
 
 	ctx := c.Request().Context()
-	if err := r.srv.Delete(ctx, song); err != nil {
+	id, err := r.srv.GetId(ctx, song)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	if err := r.srv.Delete(ctx, id); err != nil {
 		return r.e.Map(err)
 	}
 
@@ -209,104 +273,478 @@ func (r *songRoutes) deleteSong(c echo.Context) error {
 }
 
 // @Summary 		Upload Song
-// @Description 	Upload a new song
+// @Description 	Upload a new song. The body may be sent either as application/json or as form data with the same fields. releaseDate is optional: if omitted, the release date of the earliest album containing the song is used. Only group and song are required when the song info service is configured: missing text, link and releaseDate are fetched from it
 // @Tags 			Songs
-// @Param			group				formData	string		true    "desired group"
-// @Param 			song				formData	string		true    "desired song"
-// @Param			releaseDate			formData	string		true    "song release date"
-// @Param 			link				formData	string		true	"link to some media"
-// @Param 			text				formData	string		true	"song lyrics"
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			song				body		common.SongRequest		true    "song data"
 // @Success			200 				{object} 	string
 // @Failure 		400					{object}    echo.HTTPError
 // @Failure			404					{object}	echo.HTTPError
 // @Failure			500					{object} 	echo.HTTPError
+// @Failure			503					{object} 	echo.HTTPError
 // @Router 			/api/v1/songs [post]
 func (r *songRoutes) uploadSong(c echo.Context) error {
-	params, _ := c.FormParams()
+	song, err := common.BindSong(c)
+	if err != nil {
+		return err
+	}
 
-	if !params.Has("song") || !params.Has("group") || !params.Has("releaseDate") || !params.Has("link") || !params.Has("text") {
-		return ErrBadBody
+	ctx := c.Request().Context()
+	if _, err := r.srv.Create(ctx, song); err != nil {
+		return r.e.Map(err)
 	}
 
-	releaseDate, err := time.Parse(time.DateOnly, params.Get("releaseDate"))
-	if err != nil {
-		return ErrBadQueryTime
+	return c.JSON(200, "Success!")
+}
+
+// @Summary 		Update Song
+// @Description 	Upload specific song data. The body may be sent either as application/json or as form data with the same fields. releaseDate is optional: if omitted, the release date of the earliest album containing the song is used
+// @Tags 			Songs
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			group				query		string					true    "initial group"
+// @Param 			song				query		string					true    "initial song"
+// @Param			upd					body		common.SongRequest		true    "edited song data"
+// @Success			200 				{object} 	string
+// @Failure 		400					{object}    echo.HTTPError
+// @Failure			404					{object}	echo.HTTPError
+// @Failure			500					{object} 	echo.HTTPError
+// @Router 			/api/v1/songs [put]
+func (r *songRoutes) updateSong(c echo.Context) error {
+	queryParams := c.QueryParams()
+
+	if !queryParams.Has("group") || !queryParams.Has("song") {
+		return ErrBadQuery
 	}
 
-	song := models.SongWithDetailPlain{
-		Song: models.Song{
-			GroupName: params.Get("group"),
-			SongName:  params.Get("song"),
-		},
-		SongDetail: models.SongDetail{
-			ReleaseDate: releaseDate,
-			Link:        params.Get("link"),
-		},
-		Text: params.Get("text"),
+	oldSong := models.Song{
+		GroupName: queryParams.Get("group"),
+		SongName:  queryParams.Get("song"),
+	}
+
+	newSong, err := common.BindSong(c)
+	if err != nil {
+		return err
 	}
 
 	ctx := c.Request().Context()
-	if err := r.srv.Create(ctx, song); err != nil {
+	id, err := r.srv.GetId(ctx, oldSong)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	if err := r.srv.Update(ctx, id, newSong); err != nil {
 		return r.e.Map(err)
 	}
 
 	return c.JSON(200, "Success!")
+
 }
 
-// @Summary 		Update Song
-// @Description 	Upload specific song data
+// @Summary 		Patch Song
+// @Description 	Partially update specific song data. Only provided fields are changed; lyrics are rewritten only if text is provided
 // @Tags 			Songs
-// @Param			group				query		string		true    "initial group"
-// @Param 			song				query		string		true    "initial song"
-// @Param			group				formData	string		true    "edited group"
-// @Param 			song				formData	string		true    "edited song"
-// @Param			releaseDate			formData	string		true    "edited release date"
-// @Param 			link				formData	string		true	"edited link to some media"
-// @Param 			text				formData	string		true	"edited song lyrics"
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			group				query		string						true    "initial group"
+// @Param 			song				query		string						true    "initial song"
+// @Param			patch				body		common.SongPatchRequest		true    "fields to update"
 // @Success			200 				{object} 	string
 // @Failure 		400					{object}    echo.HTTPError
 // @Failure			404					{object}	echo.HTTPError
 // @Failure			500					{object} 	echo.HTTPError
-// @Router 			/api/v1/songs [put]
-func (r *songRoutes) updateSong(c echo.Context) error {
+// @Router 			/api/v1/songs [patch]
+func (r *songRoutes) patchSong(c echo.Context) error {
+	queryParams := c.QueryParams()
+
+	if !queryParams.Has("group") || !queryParams.Has("song") {
+		return ErrBadQuery
+	}
+
+	song := models.Song{
+		GroupName: queryParams.Get("group"),
+		SongName:  queryParams.Get("song"),
+	}
+
+	patch, err := common.BindSongPatch(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	id, err := r.srv.GetId(ctx, song)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	if err := r.srv.Patch(ctx, id, patch); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
+
+// @Summary 		Add Credit
+// @Description 	Credit a person on a specific song as a lyricist, composer or producer. The person is created if they don't exist yet
+// @Tags 			Songs
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			group				query		string					true    "desired group"
+// @Param 			song				query		string					true    "desired song"
+// @Param			credit				body		common.CreditRequest	true    "person and role"
+// @Success			200 				{object} 	models.SongCredit
+// @Failure 		400					{object}    echo.HTTPError
+// @Failure			404					{object}	echo.HTTPError
+// @Failure			500					{object} 	echo.HTTPError
+// @Router 			/api/v1/songs/credits [post]
+func (r *songRoutes) addCredit(c echo.Context) error {
 	queryParams := c.QueryParams()
-	formParams, _ := c.FormParams()
 
 	if !queryParams.Has("group") || !queryParams.Has("song") {
 		return ErrBadQuery
 	}
 
-	if !formParams.Has("group") || !formParams.Has("song") {
+	song := models.Song{
+		GroupName: queryParams.Get("group"),
+		SongName:  queryParams.Get("song"),
+	}
+
+	credit, err := common.BindCredit(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	id, err := r.srv.GetId(ctx, song)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	added, err := r.srv.AddCredit(ctx, id, credit.Person, credit.Role)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, added)
+}
+
+// @Summary 		Remove Credit
+// @Description 	Remove a person's credit from a specific song, either in the given role or in all roles
+// @Tags 			Songs
+// @Param			group				query		string		true    "desired group"
+// @Param 			song				query		string		true    "desired song"
+// @Param 			person				query		string		true    "person id"
+// @Param 			role				query		string		false   "lyricist, composer or producer; all roles if omitted"
+// @Success			200 				{object} 	string
+// @Failure 		400					{object}    echo.HTTPError
+// @Failure			404					{object}	echo.HTTPError
+// @Failure			500					{object} 	echo.HTTPError
+// @Router 			/api/v1/songs/credits [delete]
+func (r *songRoutes) removeCredit(c echo.Context) error {
+	params := c.QueryParams()
+
+	if !params.Has("group") || !params.Has("song") || !params.Has("person") {
 		return ErrBadQuery
 	}
 
-	oldSong := models.Song{
-		GroupName: queryParams["group"][0],
-		SongName:  queryParams["song"][0],
+	song := models.Song{
+		GroupName: params.Get("group"),
+		SongName:  params.Get("song"),
 	}
 
-	parsedDate, err := time.Parse(time.DateOnly, formParams.Get("releaseDate"))
+	personId, err := uuid.Parse(params.Get("person"))
 	if err != nil {
-		return ErrBadQueryTime
+		return ErrBadQuery
 	}
 
-	newSong := models.SongWithDetailPlain{
-		Song: models.Song{
-			GroupName: formParams["group"][1],
-			SongName:  formParams["song"][1],
-		},
-		SongDetail: models.SongDetail{
-			ReleaseDate: parsedDate,
-			Link:        formParams.Get("link"),
-		},
-		Text: formParams.Get("text"),
+	role, err := common.ParseCreditRole(params)
+	if err != nil {
+		return err
 	}
 
 	ctx := c.Request().Context()
-	if err := r.srv.Update(ctx, oldSong, newSong); err != nil {
+	id, err := r.srv.GetId(ctx, song)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	if err := r.srv.RemoveCredit(ctx, id, personId, role); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
+
+// @Summary 		Get Links
+// @Description 	Get links of a specific song: the primary link (also returned as the song's link) goes first
+// @Tags 			Songs
+// @Param			group				query		string		true    "desired group"
+// @Param 			song				query		string		true    "desired song"
+// @Success			200 				{object} 	[]models.SongLink
+// @Failure 		400					{object}    echo.HTTPError
+// @Failure			404					{object}	echo.HTTPError
+// @Failure			500					{object} 	echo.HTTPError
+// @Router 			/api/v1/songs/links [get]
+func (r *songRoutes) getLinks(c echo.Context) error {
+	params := c.QueryParams()
+
+	if !params.Has("group") || !params.Has("song") {
+		return ErrBadQuery
+	}
+
+	song := models.Song{
+		GroupName: params.Get("group"),
+		SongName:  params.Get("song"),
+	}
+
+	ctx := c.Request().Context()
+	id, err := r.srv.GetId(ctx, song)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	links, err := r.srv.GetLinks(ctx, id)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, links)
+}
+
+// @Summary 		Add Link
+// @Description 	Add a link to a specific song. A song has a single primary link, so adding a primary link replaces the current one
+// @Tags 			Songs
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			group				query		string					true    "desired group"
+// @Param 			song				query		string					true    "desired song"
+// @Param			link				body		common.LinkRequest		true    "link data"
+// @Success			200 				{object} 	models.SongLink
+// @Failure 		400					{object}    echo.HTTPError
+// @Failure			404					{object}	echo.HTTPError
+// @Failure			500					{object} 	echo.HTTPError
+// @Router 			/api/v1/songs/links [post]
+func (r *songRoutes) addLink(c echo.Context) error {
+	params := c.QueryParams()
+
+	if !params.Has("group") || !params.Has("song") {
+		return ErrBadQuery
+	}
+
+	song := models.Song{
+		GroupName: params.Get("group"),
+		SongName:  params.Get("song"),
+	}
+
+	link, err := common.BindLink(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	id, err := r.srv.GetId(ctx, song)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	added, err := r.srv.AddLink(ctx, id, link)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, added)
+}
+
+// @Summary 		Remove Link
+// @Description 	Remove a link from a specific song. The primary link can't be removed, only replaced
+// @Tags 			Songs
+// @Param			group				query		string		true    "desired group"
+// @Param 			song				query		string		true    "desired song"
+// @Param 			link				query		string		true    "link id"
+// @Success			200 				{object} 	string
+// @Failure 		400					{object}    echo.HTTPError
+// @Failure			404					{object}	echo.HTTPError
+// @Failure			500					{object} 	echo.HTTPError
+// @Router 			/api/v1/songs/links [delete]
+func (r *songRoutes) removeLink(c echo.Context) error {
+	params := c.QueryParams()
+
+	if !params.Has("group") || !params.Has("song") || !params.Has("link") {
+		return ErrBadQuery
+	}
+
+	song := models.Song{
+		GroupName: params.Get("group"),
+		SongName:  params.Get("song"),
+	}
+
+	linkId, err := uuid.Parse(params.Get("link"))
+	if err != nil {
+		return ErrBadQuery
+	}
+
+	ctx := c.Request().Context()
+	id, err := r.srv.GetId(ctx, song)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	if err := r.srv.RemoveLink(ctx, id, linkId); err != nil {
 		return r.e.Map(err)
 	}
 
 	return c.JSON(200, "Success!")
+}
+
+// Разбирает фильтры песен из параметров запроса
+func parseFilter(params url.Values) (models.Filter, error) {
+	var group, song, artist *string
+	var releasedBefore, releasedAfter *time.Time
+
+	if params.Has("group") {
+		param := params.Get("group")
+		group = &param
+	}
+
+	if params.Has("song") {
+		param := params.Get("song")
+		song = &param
+	}
+
+	if params.Has("artist") {
+		param := params.Get("artist")
+		artist = &param
+	}
+
+	if params.Has("releasedBefore") {
+		parsedBefore, err := time.Parse(time.DateOnly, params.Get("releasedBefore"))
+		if err != nil {
+			return models.Filter{}, ErrBadQueryTime
+		}
+		releasedBefore = &parsedBefore
+	}
+
+	if params.Has("releasedAfter") {
+		parsedAfter, err := time.Parse(time.DateOnly, params.Get("releasedAfter"))
+		if err != nil {
+			return models.Filter{}, ErrBadQueryTime
+		}
+		releasedAfter = &parsedAfter
+	}
+
+	match := models.MatchExact
+	if params.Has("match") {
+		match = strings.ToLower(params.Get("match"))
+		if !slices.Contains(models.MatchModes, match) {
+			return models.Filter{}, ErrBadQueryMatch
+		}
+	}
+
+	var sort []models.SortKey
+	if params.Has("sort") {
+		parsedSort, err := common.ParseSort(params.Get("sort"))
+		if err != nil {
+			return models.Filter{}, err
+		}
+		sort = parsedSort
+	}
+
+	var tags, excludeTags, genres, excludeGenres []string
+	for key, dst := range map[string]*[]string{
+		"tags":          &tags,
+		"excludeTags":   &excludeTags,
+		"genres":        &genres,
+		"excludeGenres": &excludeGenres,
+	} {
+		if !params.Has(key) {
+			continue
+		}
+		parsed, err := common.ParseList(params.Get(key))
+		if err != nil {
+			return models.Filter{}, err
+		}
+		*dst = parsed
+	}
+
+	tagMatch, err := common.ParseSetMatch(params, "tagMatch")
+	if err != nil {
+		return models.Filter{}, err
+	}
+
+	genreMatch, err := common.ParseSetMatch(params, "genreMatch")
+	if err != nil {
+		return models.Filter{}, err
+	}
+
+	var minDuration, maxDuration, minBPM, maxBPM *int
+	for key, dst := range map[string]**int{
+		"minDuration": &minDuration,
+		"maxDuration": &maxDuration,
+		"minBpm":      &minBPM,
+		"maxBpm":      &maxBPM,
+	} {
+		if !params.Has(key) {
+			continue
+		}
+		parsed, err := strconv.Atoi(params.Get(key))
+		if err != nil {
+			return models.Filter{}, echo.NewHTTPError(400, fmt.Sprintf("%v should be an integer", key))
+		}
+		*dst = &parsed
+	}
+
+	var explicit *bool
+	if params.Has("explicit") {
+		parsed, err := strconv.ParseBool(params.Get("explicit"))
+		if err != nil {
+			return models.Filter{}, echo.NewHTTPError(400, "explicit should be a boolean")
+		}
+		explicit = &parsed
+	}
 
+	// значения приводятся к тому же виду, в котором хранятся
+	var language, key, isrc *string
+	for param, field := range map[string]struct {
+		dst       **string
+		normalize func(*string) (*string, error)
+	}{
+		"language": {&language, models.NormalizeLanguage},
+		"key":      {&key, models.NormalizeKey},
+		"isrc":     {&isrc, models.NormalizeISRC},
+	} {
+		if !params.Has(param) {
+			continue
+		}
+		val := params.Get(param)
+		normalized, err := field.normalize(&val)
+		if err != nil {
+			return models.Filter{}, echo.NewHTTPError(400, err.Error())
+		}
+		*field.dst = normalized
+	}
+
+	return models.Filter{
+		Group:          group,
+		Song:           song,
+		Artist:         artist,
+		ReleasedBefore: releasedBefore,
+		ReleasedAfter:  releasedAfter,
+		Match:          match,
+		Tags:           tags,
+		ExcludeTags:    excludeTags,
+		TagMatch:       tagMatch,
+		Genres:         genres,
+		ExcludeGenres:  excludeGenres,
+		GenreMatch:     genreMatch,
+		MinDuration:    minDuration,
+		MaxDuration:    maxDuration,
+		MinBPM:         minBPM,
+		MaxBPM:         maxBPM,
+		Language:       language,
+		Explicit:       explicit,
+		Key:            key,
+		ISRC:           isrc,
+		Sort:           sort,
+	}, nil
 }
@@ -0,0 +1,71 @@
+package v1
+
+import (
+	"github.com/cutlery47/music-storage/internal/controller/http/common"
+	"github.com/cutlery47/music-storage/internal/service"
+	"github.com/labstack/echo/v4"
+)
+
+type tagRoutes struct {
+	srv service.Service
+	e   *common.ErrMapper
+}
+
+func newTagRoutes(g *echo.Group, srv service.Service, e *common.ErrMapper) {
+	r := &tagRoutes{
+		srv: srv,
+		e:   e,
+	}
+
+	g.GET("", r.getTagCounts)
+}
+
+// @Summary 		Get Tag Counts
+// @Description 	Get the most used tags among songs matching the specified filters, along with song counts
+// @Tags 			Tags
+// @Param			group				query		string		false   "desired group"
+// @Param 			song				query		string		false   "desired song"
+// @Param 			artist				query		string		false   "any credited artist: primary, featured or remixer"
+// @Param			releasedBefore		query		string		false   "upper time-bound for when the song was released"
+// @Param 			releasedAfter		query		string		false	"lower time-bound for when the song was released"
+// @Param			match				query		string		false	"how group, song and artist are matched: exact (default), icase, prefix or fuzzy"
+// @Param			tags				query		string		false	"comma-separated tags the song should have"
+// @Param			excludeTags			query		string		false	"comma-separated tags the song should not have"
+// @Param			tagMatch			query		string		false	"how tags are matched: all (default) or any"
+// @Param			genres				query		string		false	"comma-separated genres the song should belong to, subgenres included"
+// @Param			excludeGenres		query		string		false	"comma-separated genres the song should not belong to, subgenres included"
+// @Param			genreMatch			query		string		false	"how genres are matched: all (default) or any"
+// @Param			minDuration			query		int			false	"lower bound for song duration in seconds"
+// @Param			maxDuration			query		int			false	"upper bound for song duration in seconds"
+// @Param			minBpm				query		int			false	"lower bound for song tempo"
+// @Param			maxBpm				query		int			false	"upper bound for song tempo"
+// @Param			language			query		string		false	"lyrics language, ISO 639-1 code"
+// @Param			explicit			query		bool		false	"whether the song has explicit lyrics"
+// @Param			key					query		string		false	"musical key, e.g. C, F#, Bbm or A minor"
+// @Param			isrc				query		string		false	"ISRC code, hyphens are optional"
+// @Param			limit				query		int			true    "maximum number of tags"
+// @Success			200 				{object} 	[]models.TagCount
+// @Failure 		400					{object}    echo.HTTPError
+// @Failure			500					{object} 	echo.HTTPError
+// @Router 			/api/v1/tags [get]
+func (r *tagRoutes) getTagCounts(c echo.Context) error {
+	params := c.QueryParams()
+
+	filter, err := parseFilter(params)
+	if err != nil {
+		return err
+	}
+
+	limit, err := common.ParseLimit(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	ctx := c.Request().Context()
+	counts, err := r.srv.GetTagCounts(ctx, limit, filter)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, counts)
+}
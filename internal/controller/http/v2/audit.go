@@ -0,0 +1,133 @@
+package v2
+
+import (
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/cutlery47/music-storage/internal/controller/http/common"
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/cutlery47/music-storage/internal/service"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type auditRoutes struct {
+	srv service.Service
+	e   *common.ErrMapper
+}
+
+func newAuditRoutes(g *echo.Group, srv service.Service, e *common.ErrMapper) {
+	r := &auditRoutes{
+		srv: srv,
+		e:   e,
+	}
+
+	g.GET("", r.getAudit)
+}
+
+// @Summary 		Get Audit Log
+// @Description 	Get the audit log of changes to songs, albums, groups, people and genres, newest first. Each entry holds the author (X-Author header; not authenticated, accepted only from trusted proxies when HTTP_TRUSTED_PROXIES is set), action, entity type and id, entity state before and after the change, request id (X-Request-Id header) and client IP. Purging a song from the trash is logged with the purge action and no author
+// @Tags 			Audit
+// @Param			limit		query		int			true    "pagination limit"
+// @Param 			offset		query		int			true	"pagination offset"
+// @Param			actor		query		string		false	"author of the change"
+// @Param			action		query		string		false	"action (create, update, delete, restore, purge)"
+// @Param			entityType	query		string		false	"entity type (song, album, group, person, genre)"
+// @Param			entityId	query		string		false	"entity id"
+// @Param			from		query		string		false	"changes made at or after this time (RFC 3339 or YYYY-MM-DD)"
+// @Param			to			query		string		false	"changes made before this time (RFC 3339 or YYYY-MM-DD)"
+// @Success			200 		{object} 	common.PageMeta{items=[]models.AuditEntry}
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/audit [get]
+func (r *auditRoutes) getAudit(c echo.Context) error {
+	params := c.QueryParams()
+
+	limit, err := common.ParseLimit(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	offset, err := common.ParseOffset(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	filter, err := parseAuditFilter(params)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	audit, err := r.srv.GetAudit(ctx, limit, offset, filter)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	page := common.OffsetPage(audit.Entries, audit.Total, limit, offset)
+	page.SetLinks(c)
+
+	return c.JSON(200, page)
+}
+
+func parseAuditFilter(params url.Values) (models.AuditFilter, error) {
+	filter := models.AuditFilter{}
+
+	if params.Has("actor") {
+		actor := params.Get("actor")
+		filter.Actor = &actor
+	}
+
+	if params.Has("action") {
+		action := params.Get("action")
+		if !slices.Contains(models.AuditActions, action) {
+			return models.AuditFilter{}, ErrBadQueryAction
+		}
+		filter.Action = &action
+	}
+
+	if params.Has("entityType") {
+		entityType := params.Get("entityType")
+		if !slices.Contains(models.EntityTypes, entityType) {
+			return models.AuditFilter{}, ErrBadQueryEntity
+		}
+		filter.EntityType = &entityType
+	}
+
+	if params.Has("entityId") {
+		id, err := uuid.Parse(params.Get("entityId"))
+		if err != nil {
+			return models.AuditFilter{}, ErrBadQueryId
+		}
+		filter.EntityID = &id
+	}
+
+	for _, bound := range []struct {
+		param string
+		dst   **time.Time
+	}{
+		{"from", &filter.From},
+		{"to", &filter.To},
+	} {
+		if !params.Has(bound.param) {
+			continue
+		}
+
+		parsed, err := parseTime(params.Get(bound.param))
+		if err != nil {
+			return models.AuditFilter{}, ErrBadQueryTime
+		}
+		*bound.dst = &parsed
+	}
+
+	return filter, nil
+}
+
+// Время в формате RFC 3339 или дата (начало дня в UTC)
+func parseTime(val string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, val); err == nil {
+		return parsed, nil
+	}
+	return time.Parse(time.DateOnly, val)
+}
@@ -0,0 +1,26 @@
+package v2
+
+import (
+	"github.com/cutlery47/music-storage/internal/controller/http/common"
+	"github.com/cutlery47/music-storage/internal/service"
+	"github.com/labstack/echo/v4"
+	"github.com/sirupsen/logrus"
+)
+
+// Регистрирует маршруты /api/v2. Общие эндпойнты (healthcheck, swagger) регистрирует v1
+func NewController(e *echo.Echo, srv service.Service, infoLog, errLog *logrus.Logger) {
+	v2 := e.Group("/api/v2/songs", common.RequestLoggerMiddleware(infoLog))
+	{
+		newSongRoutes(v2, srv, common.NewErrMapper(errLog))
+	}
+
+	v2Trash := e.Group("/api/v2/trash", common.RequestLoggerMiddleware(infoLog))
+	{
+		newTrashRoutes(v2Trash, srv, common.NewErrMapper(errLog))
+	}
+
+	v2Audit := e.Group("/api/v2/audit", common.RequestLoggerMiddleware(infoLog))
+	{
+		newAuditRoutes(v2Audit, srv, common.NewErrMapper(errLog))
+	}
+}
@@ -0,0 +1,20 @@
+package v2
+
+import (
+	"fmt"
+	"github.com/cutlery47/music-storage/internal/controller/http/common"
+	"strings"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	ErrBadQueryPagination = common.ErrBadQueryPagination
+	ErrBadPathId          = echo.NewHTTPError(400, "couldn't parse provided id...")
+	ErrBadRevision        = echo.NewHTTPError(400, "couldn't parse provided revision number...")
+	ErrBadQueryId         = echo.NewHTTPError(400, "couldn't parse provided entityId...")
+	ErrBadQueryTime       = echo.NewHTTPError(400, "from and to should be either RFC 3339 timestamps or dates (YYYY-MM-DD)")
+	ErrBadQueryAction     = echo.NewHTTPError(400, fmt.Sprintf("action should be one of: %v", strings.Join(models.AuditActions, ", ")))
+	ErrBadQueryEntity     = echo.NewHTTPError(400, fmt.Sprintf("entityType should be one of: %v", strings.Join(models.EntityTypes, ", ")))
+)
@@ -0,0 +1,153 @@
+package v2
+
+import (
+	"strconv"
+
+	"github.com/cutlery47/music-storage/internal/controller/http/common"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type restoredResponse struct {
+	Revision int `json:"revision"`
+}
+
+// @Summary 		Get Revisions
+// @Description 	Get song revisions, newest first. A revision is written on every create, update, patch and restore
+// @Tags 			Songs v2
+// @Param			id			path		string		true    "song id"
+// @Param			limit		query		int			true    "pagination limit"
+// @Param 			offset		query		int			true	"pagination offset"
+// @Success			200 		{object} 	common.PageMeta{items=[]models.Revision}
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id}/revisions [get]
+func (r *songRoutes) getRevisions(c echo.Context) error {
+	params := c.QueryParams()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	limit, err := common.ParseLimit(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	offset, err := common.ParseOffset(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	ctx := c.Request().Context()
+	revs, err := r.srv.GetRevisions(ctx, id, limit, offset)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	page := common.OffsetPage(revs.Revisions, revs.Total, limit, offset)
+	page.SetLinks(c)
+
+	return c.JSON(200, page)
+}
+
+// @Summary 		Get Revision
+// @Description 	Get a song revision with its details and full lyrics. releaseDate is the one set explicitly, without the album fallback
+// @Tags 			Songs v2
+// @Param			id			path		string		true    "song id"
+// @Param			rev			path		int			true    "revision number"
+// @Success			200 		{object} 	models.RevisionWithDetail
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id}/revisions/{rev} [get]
+func (r *songRoutes) getRevision(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	num, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		return ErrBadRevision
+	}
+
+	ctx := c.Request().Context()
+	rev, err := r.srv.GetRevision(ctx, id, num)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, rev)
+}
+
+// @Summary 		Diff Revisions
+// @Description 	Compare two song revisions: changed fields and lyrics verse by verse. Verses are marked as unchanged, added, removed or changed
+// @Tags 			Songs v2
+// @Param			id			path		string		true    "song id"
+// @Param			from		query		int			true    "older revision number"
+// @Param			to			query		int			true    "newer revision number"
+// @Success			200 		{object} 	models.RevisionDiff
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id}/revisions/diff [get]
+func (r *songRoutes) diffRevisions(c echo.Context) error {
+	params := c.QueryParams()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	from, err := strconv.Atoi(params.Get("from"))
+	if err != nil {
+		return ErrBadRevision
+	}
+
+	to, err := strconv.Atoi(params.Get("to"))
+	if err != nil {
+		return ErrBadRevision
+	}
+
+	ctx := c.Request().Context()
+	diff, err := r.srv.DiffRevisions(ctx, id, from, to)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, diff)
+}
+
+// @Summary 		Restore Revision
+// @Description 	Restore song details and lyrics from a revision. The result is written as a new revision, so the history is never rewritten. Credits other than the primary artist, genres, tags and additional links are left as they are
+// @Tags 			Songs v2
+// @Param			id			path		string		true    "song id"
+// @Param			rev			path		int			true    "revision number"
+// @Param			X-Author	header		string		false   "author of the change"
+// @Success			200 		{object} 	restoredResponse
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id}/revisions/{rev}/restore [post]
+func (r *songRoutes) restoreRevision(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	num, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		return ErrBadRevision
+	}
+
+	ctx := c.Request().Context()
+	restored, err := r.srv.RestoreRevision(ctx, id, num)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, restoredResponse{Revision: restored})
+}
@@ -0,0 +1,477 @@
+package v2
+
+import (
+	"github.com/cutlery47/music-storage/internal/controller/http/common"
+	"github.com/cutlery47/music-storage/internal/service"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type songRoutes struct {
+	srv service.Service
+	e   *common.ErrMapper
+}
+
+func newSongRoutes(g *echo.Group, srv service.Service, e *common.ErrMapper) {
+	r := &songRoutes{
+		srv: srv,
+		e:   e,
+	}
+
+	g.POST("", r.uploadSong)
+	g.GET("/:id", r.getSong)
+	g.GET("/:id/text", r.getText)
+	g.PUT("/:id", r.updateSong)
+	g.PATCH("/:id", r.patchSong)
+	g.DELETE("/:id", r.deleteSong)
+	g.POST("/:id/credits", r.addCredit)
+	g.DELETE("/:id/credits/:personId", r.removeCredit)
+	g.GET("/:id/tags", r.getTags)
+	g.PUT("/:id/tags", r.setTags)
+	g.GET("/:id/genres", r.getGenres)
+	g.PUT("/:id/genres", r.setGenres)
+	g.GET("/:id/links", r.getLinks)
+	g.POST("/:id/links", r.addLink)
+	g.DELETE("/:id/links/:linkId", r.removeLink)
+	g.GET("/:id/revisions", r.getRevisions)
+	g.GET("/:id/revisions/diff", r.diffRevisions)
+	g.GET("/:id/revisions/:rev", r.getRevision)
+	g.POST("/:id/revisions/:rev/restore", r.restoreRevision)
+}
+
+type createdResponse struct {
+	ID uuid.UUID `json:"id"`
+}
+
+// @Summary 		Upload Song
+// @Description 	Upload a new song and get its id. The body may be sent either as application/json or as form data with the same fields. releaseDate is optional: if omitted, the release date of the earliest album containing the song is used. Only group and song are required when the song info service is configured: missing text, link and releaseDate are fetched from it
+// @Tags 			Songs v2
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			song				body		common.SongRequest		true    "song data"
+// @Param			X-Author			header		string					false   "author of the change, saved in the revision"
+// @Success			200 				{object} 	createdResponse
+// @Failure 		400					{object}    echo.HTTPError
+// @Failure			500					{object} 	echo.HTTPError
+// @Failure			503					{object} 	echo.HTTPError
+// @Router 			/api/v2/songs [post]
+func (r *songRoutes) uploadSong(c echo.Context) error {
+	song, err := common.BindSong(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	id, err := r.srv.Create(ctx, song)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, createdResponse{ID: id})
+}
+
+// @Summary 		Get Song
+// @Description 	Get a song with its details by id
+// @Tags 			Songs v2
+// @Param			id			path		string		true    "song id"
+// @Success			200 		{object} 	models.SongWithDetail
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id} [get]
+func (r *songRoutes) getSong(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	ctx := c.Request().Context()
+	song, err := r.srv.GetSong(ctx, id)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, song)
+}
+
+// @Summary 		Get Text
+// @Description 	Get song lyrics page by id with pagination metadata; links to neighbouring pages are also sent in the Link header. Responds with plain text if text/plain is preferred in the Accept header
+// @Tags 			Songs v2
+// @Produce			json
+// @Produce			plain
+// @Param			id			path		string		true    "song id"
+// @Param			limit		query		int			true    "pagination limit"
+// @Param 			offset		query		int			false	"pagination offset (required without cursor)"
+// @Param 			cursor		query		string		false	"pagination cursor from a previous page; empty for the first page"
+// @Param			section		query		string		false	"return only verses of this section type (intro, verse, pre-chorus, chorus, bridge, hook, outro, other)"
+// @Param			collapse	query		bool		false	"skip repeated verses (e.g. repeated choruses)"
+// @Param			labels		query		bool		false	"prefix verses with section labels, e.g. [Chorus] (text/plain only)"
+// @Success			200 		{object} 	common.PageMeta{items=[]models.Verse}
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id}/text [get]
+func (r *songRoutes) getText(c echo.Context) error {
+	params := c.QueryParams()
+
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	query, err := common.ParseTextQuery(params)
+	if err != nil {
+		return err
+	}
+
+	return common.WriteText(c, r.srv, r.e, id, query)
+}
+
+// @Summary 		Update Song
+// @Description 	Replace song data by id. The body may be sent either as application/json or as form data with the same fields. releaseDate is optional: if omitted, the release date of the earliest album containing the song is used
+// @Tags 			Songs v2
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			id					path		string					true    "song id"
+// @Param			upd					body		common.SongRequest		true    "edited song data"
+// @Param			X-Author			header		string					false   "author of the change, saved in the revision"
+// @Success			200 				{object} 	string
+// @Failure 		400					{object}    echo.HTTPError
+// @Failure			404					{object}	echo.HTTPError
+// @Failure			500					{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id} [put]
+func (r *songRoutes) updateSong(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	upd, err := common.BindSong(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	if err := r.srv.Update(ctx, id, upd); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
+
+// @Summary 		Patch Song
+// @Description 	Partially update song data by id. Only provided fields are changed; lyrics are rewritten only if text is provided
+// @Tags 			Songs v2
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			id					path		string						true    "song id"
+// @Param			patch				body		common.SongPatchRequest		true    "fields to update"
+// @Param			X-Author			header		string						false   "author of the change, saved in the revision"
+// @Success			200 				{object} 	string
+// @Failure 		400					{object}    echo.HTTPError
+// @Failure			404					{object}	echo.HTTPError
+// @Failure			500					{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id} [patch]
+func (r *songRoutes) patchSong(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	patch, err := common.BindSongPatch(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	if err := r.srv.Patch(ctx, id, patch); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
+
+// @Summary 		Delete Song
+// @Description 	Move a song to the trash by id. It can be restored via /api/v2/trash/{id}/restore until it is permanently removed after the retention period
+// @Tags 			Songs v2
+// @Param			id			path		string		true    "song id"
+// @Success			200 		{object} 	string
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id} [delete]
+func (r *songRoutes) deleteSong(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	ctx := c.Request().Context()
+	if err := r.srv.Delete(ctx, id); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
+
+// @Summary 		Add Credit
+// @Description 	Credit a person on a song as a lyricist, composer or producer. The person is created if they don't exist yet
+// @Tags 			Songs v2
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			id			path		string					true    "song id"
+// @Param			credit		body		common.CreditRequest	true    "person and role"
+// @Success			200 		{object} 	models.SongCredit
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id}/credits [post]
+func (r *songRoutes) addCredit(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	credit, err := common.BindCredit(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	added, err := r.srv.AddCredit(ctx, id, credit.Person, credit.Role)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, added)
+}
+
+// @Summary 		Remove Credit
+// @Description 	Remove a person's credit from a song, either in the given role or in all roles
+// @Tags 			Songs v2
+// @Param			id			path		string		true    "song id"
+// @Param			personId	path		string		true    "person id"
+// @Param 			role		query		string		false   "lyricist, composer or producer; all roles if omitted"
+// @Success			200 		{object} 	string
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id}/credits/{personId} [delete]
+func (r *songRoutes) removeCredit(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	personId, err := uuid.Parse(c.Param("personId"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	role, err := common.ParseCreditRole(c.QueryParams())
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	if err := r.srv.RemoveCredit(ctx, id, personId, role); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
+
+// @Summary 		Get Tags
+// @Description 	Get song's tags in alphabetical order
+// @Tags 			Songs v2
+// @Param			id			path		string		true    "song id"
+// @Success			200 		{object} 	[]string
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id}/tags [get]
+func (r *songRoutes) getTags(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	ctx := c.Request().Context()
+	tags, err := r.srv.GetSongTags(ctx, id)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, tags)
+}
+
+// @Summary 		Set Tags
+// @Description 	Replace song's tags. Tags are free-form and stored in lowercase; an empty list removes all tags
+// @Tags 			Songs v2
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			id			path		string					true    "song id"
+// @Param			tags		body		common.TagsRequest		true    "new tags"
+// @Success			200 		{object} 	string
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id}/tags [put]
+func (r *songRoutes) setTags(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	tags, err := common.BindTags(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	if err := r.srv.SetSongTags(ctx, id, tags); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
+
+// @Summary 		Get Genres
+// @Description 	Get song's genres in alphabetical order
+// @Tags 			Songs v2
+// @Param			id			path		string		true    "song id"
+// @Success			200 		{object} 	[]string
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id}/genres [get]
+func (r *songRoutes) getGenres(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	ctx := c.Request().Context()
+	genres, err := r.srv.GetSongGenres(ctx, id)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, genres)
+}
+
+// @Summary 		Set Genres
+// @Description 	Replace song's genres. All genres should already exist; an empty list removes all genres
+// @Tags 			Songs v2
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			id			path		string					true    "song id"
+// @Param			genres		body		common.GenresRequest	true    "new genres"
+// @Success			200 		{object} 	string
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id}/genres [put]
+func (r *songRoutes) setGenres(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	genres, err := common.BindGenres(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	if err := r.srv.SetSongGenres(ctx, id, genres); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
+
+// @Summary 		Get Links
+// @Description 	Get song's links: the primary link (also returned as the song's link) goes first
+// @Tags 			Songs v2
+// @Param			id			path		string		true    "song id"
+// @Success			200 		{object} 	[]models.SongLink
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id}/links [get]
+func (r *songRoutes) getLinks(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	ctx := c.Request().Context()
+	links, err := r.srv.GetLinks(ctx, id)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, links)
+}
+
+// @Summary 		Add Link
+// @Description 	Add a link to a song. A song has a single primary link, so adding a primary link replaces the current one
+// @Tags 			Songs v2
+// @Accept			json
+// @Accept			x-www-form-urlencoded
+// @Param			id			path		string					true    "song id"
+// @Param			link		body		common.LinkRequest		true    "link data"
+// @Success			200 		{object} 	models.SongLink
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id}/links [post]
+func (r *songRoutes) addLink(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	link, err := common.BindLink(c)
+	if err != nil {
+		return err
+	}
+
+	ctx := c.Request().Context()
+	added, err := r.srv.AddLink(ctx, id, link)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, added)
+}
+
+// @Summary 		Remove Link
+// @Description 	Remove a link from a song. The primary link can't be removed, only replaced
+// @Tags 			Songs v2
+// @Param			id			path		string		true    "song id"
+// @Param			linkId		path		string		true    "link id"
+// @Success			200 		{object} 	string
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/songs/{id}/links/{linkId} [delete]
+func (r *songRoutes) removeLink(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	linkId, err := uuid.Parse(c.Param("linkId"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	ctx := c.Request().Context()
+	if err := r.srv.RemoveLink(ctx, id, linkId); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
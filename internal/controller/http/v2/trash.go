@@ -0,0 +1,80 @@
+package v2
+
+import (
+	"github.com/cutlery47/music-storage/internal/controller/http/common"
+	"github.com/cutlery47/music-storage/internal/service"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type trashRoutes struct {
+	srv service.Service
+	e   *common.ErrMapper
+}
+
+func newTrashRoutes(g *echo.Group, srv service.Service, e *common.ErrMapper) {
+	r := &trashRoutes{
+		srv: srv,
+		e:   e,
+	}
+
+	g.GET("", r.getTrash)
+	g.POST("/:id/restore", r.restoreSong)
+}
+
+// @Summary 		Get Trash
+// @Description 	Get deleted songs, most recently deleted first. Deleted songs are hidden from all other endpoints and are permanently removed after the configured retention period
+// @Tags 			Trash
+// @Param			limit		query		int			true    "pagination limit"
+// @Param 			offset		query		int			true	"pagination offset"
+// @Success			200 		{object} 	common.PageMeta{items=[]models.DeletedSong}
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/trash [get]
+func (r *trashRoutes) getTrash(c echo.Context) error {
+	params := c.QueryParams()
+
+	limit, err := common.ParseLimit(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	offset, err := common.ParseOffset(params)
+	if err != nil {
+		return ErrBadQueryPagination
+	}
+
+	ctx := c.Request().Context()
+	trash, err := r.srv.GetTrash(ctx, limit, offset)
+	if err != nil {
+		return r.e.Map(err)
+	}
+
+	page := common.OffsetPage(trash.Songs, trash.Total, limit, offset)
+	page.SetLinks(c)
+
+	return c.JSON(200, page)
+}
+
+// @Summary 		Restore Song
+// @Description 	Restore a deleted song with its lyrics, details, links and credits. Fails if the group already has another song with the same name or the ISRC of the song is taken by another song
+// @Tags 			Trash
+// @Param			id			path		string		true    "song id"
+// @Success			200 		{object} 	string
+// @Failure 		400			{object}    echo.HTTPError
+// @Failure			404			{object}	echo.HTTPError
+// @Failure			500			{object} 	echo.HTTPError
+// @Router 			/api/v2/trash/{id}/restore [post]
+func (r *trashRoutes) restoreSong(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return ErrBadPathId
+	}
+
+	ctx := c.Request().Context()
+	if err := r.srv.RestoreSong(ctx, id); err != nil {
+		return r.e.Map(err)
+	}
+
+	return c.JSON(200, "Success!")
+}
@@ -0,0 +1,298 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/cutlery47/music-storage/internal/config"
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/time/rate"
+)
+
+const (
+	userAgent = "music-storage-linkcheck/1.0"
+	// максимальное количество перенаправлений
+	maxRedirects = 10
+
+	defaultInterval     = 10 * time.Minute
+	defaultRecheckAfter = 24 * time.Hour
+	defaultBatchSize    = 100
+	defaultTimeout      = 5 * time.Second
+)
+
+var ErrForbiddenAddress = errors.New("destination address is not allowed")
+
+// общее адресное пространство провайдеров (RFC 6598)
+var cgnat = netip.MustParsePrefix("100.64.0.0/10")
+
+// Хранилище ссылок. Реализуется repository.MusicRepository
+type Store interface {
+	ReadLinksToCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]models.SongLink, error)
+	UpdateLinkCheck(ctx context.Context, id uuid.UUID, check models.LinkCheck) error
+}
+
+// Фоновая проверка ссылок на песни. Ссылки проверяются пачками раз в interval,
+// одновременно выполняется не больше workers запросов, а к одному хосту - не больше hostRate запросов в секунду
+type Checker struct {
+	store  Store
+	client *http.Client
+	errLog *logrus.Logger
+
+	interval     time.Duration
+	recheckAfter time.Duration
+	batchSize    int
+	workers      int
+	hostRate     rate.Limit
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+	// источник текущего времени, подменяется опцией Clock
+	now func() time.Time
+}
+
+func New(store Store, conf config.LinkCheckConfig, errLog *logrus.Logger, opts ...Option) *Checker {
+	c := &Checker{
+		store:        store,
+		errLog:       errLog,
+		interval:     orDefault(conf.LinkCheckInterval, defaultInterval),
+		recheckAfter: orDefault(conf.LinkCheckRecheckAfter, defaultRecheckAfter),
+		batchSize:    orDefault(conf.LinkCheckBatchSize, defaultBatchSize),
+		workers:      orDefault(conf.LinkCheckWorkers, 1),
+		hostRate:     rate.Limit(conf.LinkCheckHostRate),
+		limiters:     map[string]*rate.Limiter{},
+		now:          time.Now,
+	}
+
+	// нулевая частота означает отсутствие ограничения
+	if conf.LinkCheckHostRate <= 0 {
+		c.hostRate = rate.Inf
+	}
+
+	// соединения с внутренними адресами запрещены, чтобы проверку ссылок
+	// нельзя было использовать для сканирования внутренней сети
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// через прокси адрес назначения проверить нельзя
+	transport.Proxy = nil
+	transport.DialContext = (&net.Dialer{
+		Timeout: orDefault(conf.LinkCheckTimeout, defaultTimeout),
+		Control: denyPrivate,
+	}).DialContext
+
+	c.client = &http.Client{
+		Timeout:       orDefault(conf.LinkCheckTimeout, defaultTimeout),
+		Transport:     transport,
+		CheckRedirect: c.checkRedirect,
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	// подмененный клиент без своей политики перенаправлений тоже соблюдает ограничения
+	if c.client.CheckRedirect == nil {
+		c.client.CheckRedirect = c.checkRedirect
+	}
+
+	return c
+}
+
+// Запускает проверку ссылок раз в interval до отмены контекста. Первый проход выполняется сразу
+func (c *Checker) Run(ctx context.Context) {
+	logrus.Debug("running link checker...")
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.CheckOnce(ctx); err != nil && !errors.Is(err, context.Canceled) {
+			c.errLog.Error(fmt.Sprintf("link check failed: %v", err))
+		}
+
+		select {
+		case <-ctx.Done():
+			logrus.Debug("link checker stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Один проход: проверяет давно не проверявшиеся ссылки и сохраняет результаты
+func (c *Checker) CheckOnce(ctx context.Context) error {
+	links, err := c.store.ReadLinksToCheck(ctx, c.now().Add(-c.recheckAfter), c.batchSize)
+	if err != nil {
+		return fmt.Errorf("c.store.ReadLinksToCheck: %v", err)
+	}
+
+	jobs := make(chan models.SongLink)
+	wg := sync.WaitGroup{}
+
+	for range c.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for link := range jobs {
+				check := c.Check(ctx, link.URL)
+				// проверка прервана остановкой приложения, результат не сохраняем
+				if ctx.Err() != nil {
+					continue
+				}
+				if err := c.store.UpdateLinkCheck(ctx, link.ID, check); err != nil {
+					c.errLog.Error(fmt.Sprintf("c.store.UpdateLinkCheck: %v", err))
+				}
+			}
+		}()
+	}
+
+	for _, link := range links {
+		select {
+		case jobs <- link:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+
+	close(jobs)
+	wg.Wait()
+
+	c.pruneLimiters()
+
+	return ctx.Err()
+}
+
+// Проверяет одну ссылку. Сначала выполняется HEAD-запрос; если сервер не поддерживает HEAD
+// или отвечает на него ошибкой, ссылка перепроверяется GET-запросом
+func (c *Checker) Check(ctx context.Context, link string) models.LinkCheck {
+	check := c.request(ctx, http.MethodHead, link)
+	if check.StatusCode != nil && *check.StatusCode >= 400 {
+		check = c.request(ctx, http.MethodGet, link)
+	}
+
+	return check
+}
+
+func (c *Checker) request(ctx context.Context, method, link string) models.LinkCheck {
+	checkedAt := c.now()
+	check := models.LinkCheck{CheckedAt: &checkedAt}
+
+	parsed, err := url.Parse(link)
+	if err != nil {
+		return failed(check, err)
+	}
+
+	if err := c.limiter(parsed.Host).Wait(ctx); err != nil {
+		return failed(check, err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, link, nil)
+	if err != nil {
+		return failed(check, err)
+	}
+	req.Header.Set("User-Agent", userAgent)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return failed(check, err)
+	}
+	// тело не читаем: для проверки достаточно кода ответа
+	resp.Body.Close()
+
+	check.StatusCode = &resp.StatusCode
+	check.Broken = resp.StatusCode >= 400
+
+	// resp.Request - последний запрос в цепочке перенаправлений
+	if final := resp.Request.URL.String(); final != link {
+		check.RedirectURL = &final
+	}
+
+	return check
+}
+
+func (c *Checker) checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= maxRedirects {
+		return fmt.Errorf("stopped after %v redirects", maxRedirects)
+	}
+	// перенаправление - такой же запрос к хосту, ограничение частоты действует и на него
+	return c.limiter(req.URL.Host).Wait(req.Context())
+}
+
+func failed(check models.LinkCheck, err error) models.LinkCheck {
+	msg := err.Error()
+	// не раскрываем, во что разрешился адрес
+	if errors.Is(err, ErrForbiddenAddress) {
+		msg = ErrForbiddenAddress.Error()
+	}
+	check.Error = &msg
+	check.Broken = true
+	return check
+}
+
+// Ограничитель запросов к хосту. Создается при первом обращении
+func (c *Checker) limiter(host string) *rate.Limiter {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	limiter, ok := c.limiters[host]
+	if !ok {
+		limiter = rate.NewLimiter(c.hostRate, 1)
+		c.limiters[host] = limiter
+	}
+
+	return limiter
+}
+
+// Удаляет ограничители хостов, успевшие полностью восстановиться после последнего запроса.
+// Такой ограничитель ничем не отличается от нового, поэтому удаление не ослабляет ограничение,
+// а карта не растет с каждым новым хостом
+func (c *Checker) pruneLimiters() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for host, limiter := range c.limiters {
+		if c.hostRate == rate.Inf || limiter.Tokens() >= float64(limiter.Burst()) {
+			delete(c.limiters, host)
+		}
+	}
+}
+
+// Запрещает соединения с локальными, частными, link-local и прочими не публичными адресами.
+// Вызывается для уже разрешенного адреса, поэтому проверка действует и для перенаправлений,
+// и для доменов, указывающих на внутренние адреса
+func denyPrivate(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return err
+	}
+	ip = ip.Unmap()
+
+	if !ip.IsGlobalUnicast() || ip.IsPrivate() || ip.IsLoopback() || ip.IsLinkLocalUnicast() || cgnat.Contains(ip) {
+		return ErrForbiddenAddress
+	}
+
+	return nil
+}
+
+// Неположительные значения настроек заменяются значениями по умолчанию
+func orDefault[T int | time.Duration](val, def T) T {
+	if val <= 0 {
+		return def
+	}
+	return val
+}
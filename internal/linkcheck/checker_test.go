@@ -0,0 +1,130 @@
+package linkcheck_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cutlery47/music-storage/internal/config"
+	"github.com/cutlery47/music-storage/internal/linkcheck"
+	"github.com/sirupsen/logrus"
+)
+
+var checkedAt = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// проверка ссылок на httptest.Server: подменяем клиент, так как клиент по умолчанию не обращается к loopback
+func newChecker(srv *httptest.Server, conf config.LinkCheckConfig) *linkcheck.Checker {
+	return linkcheck.New(
+		nil,
+		conf,
+		logrus.New(),
+		linkcheck.Client(&http.Client{Transport: srv.Client().Transport}),
+		linkcheck.Clock(func() time.Time { return checkedAt }),
+	)
+}
+
+func TestCheckHeadFallback(t *testing.T) {
+	var methods []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method)
+		if r.Method == http.MethodHead {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}))
+	defer srv.Close()
+
+	check := newChecker(srv, config.LinkCheckConfig{}).Check(context.Background(), srv.URL)
+
+	if check.Broken || check.StatusCode == nil || *check.StatusCode != http.StatusOK {
+		t.Errorf("check = %+v, want working link with status 200", check)
+	}
+	if len(methods) != 2 || methods[0] != http.MethodHead || methods[1] != http.MethodGet {
+		t.Errorf("methods = %v, want [HEAD GET]", methods)
+	}
+}
+
+func TestCheckRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/old", http.RedirectHandler("/new", http.StatusMovedPermanently))
+	mux.HandleFunc("/new", func(w http.ResponseWriter, r *http.Request) {})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	check := newChecker(srv, config.LinkCheckConfig{}).Check(context.Background(), srv.URL+"/old")
+
+	if check.Broken {
+		t.Errorf("check = %+v, redirected link shouldn't be broken", check)
+	}
+	if want := srv.URL + "/new"; check.RedirectURL == nil || *check.RedirectURL != want {
+		t.Errorf("redirect url = %v, want %v", check.RedirectURL, want)
+	}
+}
+
+func TestCheckBroken(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusGone, http.StatusInternalServerError, http.StatusBadGateway} {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		check := newChecker(srv, config.LinkCheckConfig{}).Check(context.Background(), srv.URL)
+		srv.Close()
+
+		if !check.Broken || check.StatusCode == nil || *check.StatusCode != status {
+			t.Errorf("status %v: check = %+v, want broken link with the same status", status, check)
+		}
+		if check.CheckedAt == nil || !check.CheckedAt.Equal(checkedAt) {
+			t.Errorf("status %v: checked at = %v, want %v", status, check.CheckedAt, checkedAt)
+		}
+	}
+}
+
+func TestCheckRefusesInternalAddresses(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+	}))
+	defer srv.Close()
+
+	// клиент по умолчанию
+	checker := linkcheck.New(nil, config.LinkCheckConfig{}, logrus.New())
+
+	for _, link := range []string{srv.URL, "http://localhost:1/", "http://[::1]:1/", "http://169.254.169.254/", "http://10.0.0.1/"} {
+		check := checker.Check(context.Background(), link)
+		if !check.Broken || check.Error == nil || *check.Error != linkcheck.ErrForbiddenAddress.Error() {
+			t.Errorf("%v: check = %+v, want refusal", link, check)
+		}
+	}
+
+	if got := requests.Load(); got != 0 {
+		t.Errorf("requests = %v, internal server shouldn't be reached", got)
+	}
+}
+
+func TestCheckHostRate(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})
+	first := httptest.NewServer(handler)
+	defer first.Close()
+	second := httptest.NewServer(handler)
+	defer second.Close()
+
+	// не больше 10 запросов в секунду к одному хосту
+	checker := newChecker(first, config.LinkCheckConfig{LinkCheckHostRate: 10})
+
+	start := time.Now()
+	for range 3 {
+		checker.Check(context.Background(), first.URL)
+	}
+	if elapsed := time.Since(start); elapsed < 180*time.Millisecond {
+		t.Errorf("3 requests to one host took %v, want at least 200ms", elapsed)
+	}
+
+	// у другого хоста свой ограничитель
+	start = time.Now()
+	checker.Check(context.Background(), second.URL)
+	if elapsed := time.Since(start); elapsed > 50*time.Millisecond {
+		t.Errorf("request to another host took %v, shouldn't wait for the first host", elapsed)
+	}
+}
@@ -0,0 +1,24 @@
+package linkcheck
+
+import (
+	"net/http"
+	"time"
+)
+
+type Option func(*Checker)
+
+// Подменяет http-клиент. Запрет внутренних адресов встроен в клиент по умолчанию,
+// поэтому подмененный клиент может обращаться, например, к httptest.Server на loopback.
+// Клиенту без CheckRedirect назначается политика перенаправлений проверки ссылок
+func Client(client *http.Client) Option {
+	return func(c *Checker) {
+		c.client = client
+	}
+}
+
+// Подменяет источник текущего времени
+func Clock(now func() time.Time) Option {
+	return func(c *Checker) {
+		c.now = now
+	}
+}
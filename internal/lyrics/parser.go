@@ -0,0 +1,122 @@
+package lyrics
+
+import (
+	"regexp"
+	"strings"
+)
+
+// Куплет песни вместе с типом секции.
+// RepeatOf - порядковый номер (с 1) куплета, который повторяет данный, 0 - не повтор
+type Stanza struct {
+	Section  string
+	Text     string
+	RepeatOf int
+}
+
+// строка вида [Chorus], [Verse 2], [Verse 1: Artist], [Припев x2]
+var markerRe = regexp.MustCompile(`^\[([^\[\]]+)\]$`)
+
+// Разбивает текст песни на куплеты.
+// Куплеты разделяются одной или несколькими пустыми строками или метками секций ([Chorus], [Verse 2], ...),
+// переводы строк приводятся к \n, пробелы в конце строк отбрасываются.
+// Строки внутри куплета сохраняются и склеиваются через \n.
+// Метка без текста (например, повторный [Chorus]) означает повтор последней секции этого типа
+func Parse(text string) []Stanza {
+	text = normalize(text)
+
+	var stanzas []Stanza
+	// были ли у куплета явные метки
+	var labeled []bool
+
+	var lines []string
+	section, isLabeled, marker := SectionVerse, false, false
+
+	flush := func() {
+		switch {
+		case len(lines) > 0:
+			stanzas = append(stanzas, Stanza{Section: section, Text: strings.Join(lines, "\n")})
+			labeled = append(labeled, isLabeled)
+		case marker:
+			// метка без текста - повтор последней секции того же типа
+			for i := len(stanzas) - 1; i >= 0; i-- {
+				if stanzas[i].Section == section {
+					stanzas = append(stanzas, Stanza{Section: section, Text: stanzas[i].Text})
+					labeled = append(labeled, true)
+					break
+				}
+			}
+		}
+		lines = nil
+		section, isLabeled, marker = SectionVerse, false, false
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, " \t")
+
+		if strings.TrimSpace(line) == "" {
+			// пустая строка сразу после метки не завершает секцию
+			if marker && len(lines) == 0 {
+				continue
+			}
+			flush()
+			continue
+		}
+
+		if match := markerRe.FindStringSubmatch(strings.TrimSpace(line)); match != nil {
+			flush()
+			section, isLabeled, marker = ParseSection(match[1]), true, true
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+	flush()
+
+	markRepeats(stanzas, labeled)
+
+	return stanzas
+}
+
+// Отмечает повторяющиеся куплеты. Неразмеченный куплет, встречающийся в тексте
+// несколько раз, считается припевом
+func markRepeats(stanzas []Stanza, labeled []bool) {
+	first := map[string]int{}
+	count := map[string]int{}
+
+	for i, stanza := range stanzas {
+		count[stanza.Text]++
+		if j, ok := first[stanza.Text]; ok {
+			stanzas[i].RepeatOf = j + 1
+			// повтор без метки наследует секцию оригинала
+			if !labeled[i] {
+				stanzas[i].Section = stanzas[j].Section
+			}
+			continue
+		}
+		first[stanza.Text] = i
+	}
+
+	chorus := map[string]bool{}
+	for text, j := range first {
+		if count[text] > 1 && !labeled[j] && stanzas[j].Section == SectionVerse {
+			chorus[text] = true
+		}
+	}
+
+	for i, stanza := range stanzas {
+		if chorus[stanza.Text] && !labeled[i] {
+			stanzas[i].Section = SectionChorus
+		}
+	}
+}
+
+// Склеивает куплеты обратно в текст, разделяя их пустой строкой
+func Join(stanzas []string) string {
+	return strings.Join(stanzas, "\n\n")
+}
+
+// приводим CRLF и одиночные CR к LF
+func normalize(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return strings.ReplaceAll(text, "\r", "\n")
+}
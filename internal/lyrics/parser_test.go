@@ -0,0 +1,49 @@
+package lyrics
+
+import (
+	"slices"
+	"testing"
+)
+
+func texts(stanzas []Stanza) []string {
+	var res []string
+	for _, stanza := range stanzas {
+		res = append(res, stanza.Text)
+	}
+	return res
+}
+
+func TestParseSplitsStanzas(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{"empty", "", nil},
+		{"only whitespace", " \n\t\n  \n", nil},
+		{"single stanza", "one\ntwo", []string{"one\ntwo"}},
+		{"blank line", "one\ntwo\n\nthree", []string{"one\ntwo", "three"}},
+		{"run of blank lines", "one\n\n\n\ntwo", []string{"one", "two"}},
+		{"whitespace-only separator", "one\n \t \ntwo", []string{"one", "two"}},
+		{"crlf", "one\r\ntwo\r\n\r\nthree", []string{"one\ntwo", "three"}},
+		{"lone cr", "one\rtwo\r\rthree", []string{"one\ntwo", "three"}},
+		{"leading and trailing blank lines", "\n\n one\ntwo \n\n\n", []string{" one\ntwo"}},
+		{"trailing spaces", "one  \ntwo\t", []string{"one\ntwo"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := texts(Parse(tt.text)); !slices.Equal(got, tt.want) {
+				t.Errorf("Parse(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestJoinRoundTrip(t *testing.T) {
+	text := "one\ntwo\n\nthree"
+
+	if got := Join(texts(Parse(text))); got != text {
+		t.Errorf("Join(Parse(%q)) = %q", text, got)
+	}
+}
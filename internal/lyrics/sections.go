@@ -0,0 +1,102 @@
+package lyrics
+
+import (
+	"regexp"
+	"strings"
+)
+
+const (
+	SectionIntro     = "intro"
+	SectionVerse     = "verse"
+	SectionPreChorus = "pre-chorus"
+	SectionChorus    = "chorus"
+	SectionBridge    = "bridge"
+	SectionHook      = "hook"
+	SectionOutro     = "outro"
+	SectionOther     = "other"
+)
+
+// все допустимые типы секций
+var Sections = []string{
+	SectionIntro,
+	SectionVerse,
+	SectionPreChorus,
+	SectionChorus,
+	SectionBridge,
+	SectionHook,
+	SectionOutro,
+	SectionOther,
+}
+
+// синонимы меток секций (в нижнем регистре)
+var sectionAliases = map[string]string{
+	"intro":      SectionIntro,
+	"verse":      SectionVerse,
+	"pre-chorus": SectionPreChorus,
+	"prechorus":  SectionPreChorus,
+	"pre chorus": SectionPreChorus,
+	"chorus":     SectionChorus,
+	"refrain":    SectionChorus,
+	"bridge":     SectionBridge,
+	"hook":       SectionHook,
+	"outro":      SectionOutro,
+
+	"вступление":  SectionIntro,
+	"интро":       SectionIntro,
+	"куплет":      SectionVerse,
+	"предприпев":  SectionPreChorus,
+	"пред-припев": SectionPreChorus,
+	"припев":      SectionChorus,
+	"бридж":       SectionBridge,
+	"хук":         SectionHook,
+	"концовка":    SectionOutro,
+	"аутро":       SectionOutro,
+}
+
+// названия секций для отображения меток
+var sectionTitles = map[string]string{
+	SectionIntro:     "Intro",
+	SectionVerse:     "Verse",
+	SectionPreChorus: "Pre-Chorus",
+	SectionChorus:    "Chorus",
+	SectionBridge:    "Bridge",
+	SectionHook:      "Hook",
+	SectionOutro:     "Outro",
+	SectionOther:     "Other",
+}
+
+// номер куплета и количество повторов: "2", "x2", "х2"
+var sectionSuffixRe = regexp.MustCompile(`(\s+([xх]\s*)?\d+)+$`)
+
+// Определяет тип секции по тексту метки, например "Verse 2: Artist" -> verse.
+// Неизвестные метки (например, "Guitar Solo") относятся к other
+func ParseSection(label string) string {
+	label = strings.ToLower(strings.TrimSpace(label))
+
+	// в метках вида [Verse 1: Artist] отбрасываем исполнителя
+	if i := strings.Index(label, ":"); i >= 0 {
+		label = strings.TrimSpace(label[:i])
+	}
+	label = sectionSuffixRe.ReplaceAllString(label, "")
+
+	if section, ok := sectionAliases[label]; ok {
+		return section
+	}
+
+	return SectionOther
+}
+
+func IsSection(section string) bool {
+	_, ok := sectionTitles[section]
+	return ok
+}
+
+// Метка секции для вставки в текст, например [Chorus]
+func Label(section string) string {
+	title, ok := sectionTitles[section]
+	if !ok {
+		title = sectionTitles[SectionOther]
+	}
+
+	return "[" + title + "]"
+}
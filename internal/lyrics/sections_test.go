@@ -0,0 +1,121 @@
+package lyrics
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSection(t *testing.T) {
+	tests := []struct {
+		label string
+		want  string
+	}{
+		{"Chorus", SectionChorus},
+		{"CHORUS", SectionChorus},
+		{"  chorus  ", SectionChorus},
+		{"Chorus x2", SectionChorus},
+		{"Chorus X2", SectionChorus},
+		{"Припев х2", SectionChorus},
+		{"Refrain", SectionChorus},
+		{"Verse 2", SectionVerse},
+		{"Verse 1: Artist", SectionVerse},
+		{"Куплет 1: Исполнитель", SectionVerse},
+		{"Pre-Chorus", SectionPreChorus},
+		{"Pre Chorus", SectionPreChorus},
+		{"Intro", SectionIntro},
+		{"Bridge", SectionBridge},
+		{"Hook", SectionHook},
+		{"Outro", SectionOutro},
+		{"Guitar Solo", SectionOther},
+		{"Chorused", SectionOther},
+		{"", SectionOther},
+	}
+
+	for _, tt := range tests {
+		if got := ParseSection(tt.label); got != tt.want {
+			t.Errorf("ParseSection(%q) = %q, want %q", tt.label, got, tt.want)
+		}
+	}
+}
+
+func TestParseMarksSections(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []Stanza
+	}{
+		{
+			name: "labels",
+			text: "[Verse 1: Artist]\na\n\n[chorus x2]\nb",
+			want: []Stanza{
+				{Section: SectionVerse, Text: "a"},
+				{Section: SectionChorus, Text: "b"},
+			},
+		},
+		{
+			name: "blank line after label",
+			text: "[Chorus]\n\nb",
+			want: []Stanza{
+				{Section: SectionChorus, Text: "b"},
+			},
+		},
+		{
+			name: "label-only stanza repeats the last section of its type",
+			text: "[Chorus]\nb\n\n[Verse]\na\n\n[Chorus]\n\n\n[Outro]\nc",
+			want: []Stanza{
+				{Section: SectionChorus, Text: "b"},
+				{Section: SectionVerse, Text: "a"},
+				{Section: SectionChorus, Text: "b", RepeatOf: 1},
+				{Section: SectionOutro, Text: "c"},
+			},
+		},
+		{
+			name: "label-only stanza without original is dropped",
+			text: "[Bridge]\n[Verse]\na",
+			want: []Stanza{
+				{Section: SectionVerse, Text: "a"},
+			},
+		},
+		{
+			name: "unknown label",
+			text: "[Guitar Solo]\na",
+			want: []Stanza{
+				{Section: SectionOther, Text: "a"},
+			},
+		},
+		{
+			name: "unlabeled repeat becomes chorus",
+			text: "a\n\nb\n\na",
+			want: []Stanza{
+				{Section: SectionChorus, Text: "a"},
+				{Section: SectionVerse, Text: "b"},
+				{Section: SectionChorus, Text: "a", RepeatOf: 1},
+			},
+		},
+		{
+			name: "unlabeled repeat inherits label",
+			text: "[Hook]\na\n\nb\n\na",
+			want: []Stanza{
+				{Section: SectionHook, Text: "a"},
+				{Section: SectionVerse, Text: "b"},
+				{Section: SectionHook, Text: "a", RepeatOf: 1},
+			},
+		},
+		{
+			name: "labeled repeat keeps its label",
+			text: "a\n\n[Bridge]\na",
+			want: []Stanza{
+				{Section: SectionChorus, Text: "a"},
+				{Section: SectionBridge, Text: "a", RepeatOf: 1},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Parse(tt.text); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse(%q) = %+v, want %+v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
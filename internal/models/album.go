@@ -0,0 +1,43 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// типы альбомов
+const (
+	AlbumLP          = "LP"
+	AlbumEP          = "EP"
+	AlbumSingle      = "single"
+	AlbumCompilation = "compilation"
+)
+
+var AlbumTypes = []string{AlbumLP, AlbumEP, AlbumSingle, AlbumCompilation}
+
+type Album struct {
+	ID          uuid.UUID  `db:"id"`
+	GroupName   string     `db:"group_name"`
+	Title       string     `db:"title"`
+	ReleaseDate *time.Time `db:"released_at"`
+	Type        string     `db:"album_type"`
+}
+
+// альбом с количеством треков
+type AlbumWithDetail struct {
+	Album
+	TrackCount int `db:"track_count"`
+}
+
+// позиция песни в альбоме
+type TrackPosition struct {
+	DiscNum  int `db:"disc_num"`
+	TrackNum int `db:"track_num"`
+}
+
+// трек альбома
+type Track struct {
+	TrackPosition
+	SongWithDetail
+}
@@ -0,0 +1,25 @@
+package models
+
+import "github.com/google/uuid"
+
+// роли исполнителей песни
+const (
+	RolePrimary  = "primary"
+	RoleFeatured = "featured"
+	RoleRemixer  = "remixer"
+)
+
+var ArtistRoles = []string{RolePrimary, RoleFeatured, RoleRemixer}
+
+// исполнитель песни; исполнителями выступают группы
+type Artist struct {
+	ID   uuid.UUID `db:"group_id"`
+	Name string    `db:"group_name"`
+	Role string    `db:"role"`
+}
+
+// исполнитель, указанный при добавлении песни (помимо основного)
+type Credit struct {
+	Name string
+	Role string
+}
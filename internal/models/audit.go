@@ -0,0 +1,66 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// действия, попадающие в журнал аудита
+const (
+	AuditCreate  = "create"
+	AuditUpdate  = "update"
+	AuditDelete  = "delete"
+	AuditRestore = "restore"
+	// окончательное удаление песни из корзины
+	AuditPurge = "purge"
+)
+
+var AuditActions = []string{AuditCreate, AuditUpdate, AuditDelete, AuditRestore, AuditPurge}
+
+// изменяемые сущности
+const (
+	EntitySong   = "song"
+	EntityAlbum  = "album"
+	EntityGroup  = "group"
+	EntityPerson = "person"
+	EntityGenre  = "genre"
+)
+
+var EntityTypes = []string{EntitySong, EntityAlbum, EntityGroup, EntityPerson, EntityGenre}
+
+// запись журнала аудита
+type AuditEntry struct {
+	ID uuid.UUID `db:"id"`
+	// автор изменения из заголовка X-Author, nil - неизвестен.
+	// Автор не аутентифицируется: его указывает клиент или доверенный прокси (HTTP_TRUSTED_PROXIES)
+	Actor      *string   `db:"actor"`
+	Action     string    `db:"action"`
+	EntityType string    `db:"entity_type"`
+	EntityID   uuid.UUID `db:"entity_id"`
+	// состояние сущности до и после изменения, null - сущности не было
+	Before json.RawMessage `db:"before" swaggertype:"object"`
+	After  json.RawMessage `db:"after" swaggertype:"object"`
+	// id http-запроса (X-Request-Id) и адрес клиента
+	RequestID *string   `db:"request_id"`
+	IP        *string   `db:"ip"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// фильтры журнала аудита, nil - фильтр не применяется
+type AuditFilter struct {
+	Actor      *string
+	Action     *string
+	EntityType *string
+	EntityID   *uuid.UUID
+	// границы времени изменения: From включительно, To не включительно
+	From *time.Time
+	To   *time.Time
+}
+
+// страница журнала аудита
+type AuditPage struct {
+	Entries []AuditEntry
+	Total   int
+}
@@ -0,0 +1,16 @@
+package models
+
+import "github.com/google/uuid"
+
+// жанр; Parent - название родительского жанра, nil - жанр верхнего уровня
+type Genre struct {
+	ID     uuid.UUID `db:"id"`
+	Name   string    `db:"name"`
+	Parent *string   `db:"parent"`
+}
+
+// количество песен с тегом
+type TagCount struct {
+	Tag   string `db:"tag"`
+	Count int    `db:"count"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import "context"
+
+type authorKey struct{}
+
+type requestKey struct{}
+
+// сведения об http-запросе, вызвавшем изменение
+type RequestInfo struct {
+	ID string
+	IP string
+}
+
+// Сохраняет в контексте автора изменений, который попадет в ревизии песен и журнал аудита
+func WithAuthor(ctx context.Context, author string) context.Context {
+	return context.WithValue(ctx, authorKey{}, author)
+}
+
+// Автор изменений из контекста, nil - неизвестен
+func Author(ctx context.Context) *string {
+	author, ok := ctx.Value(authorKey{}).(string)
+	if !ok || author == "" {
+		return nil
+	}
+	return &author
+}
+
+// Сохраняет в контексте сведения о запросе для журнала аудита
+func WithRequest(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestKey{}, info)
+}
+
+// Сведения о запросе из контекста; вне http-запроса поля пустые
+func Request(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestKey{}).(RequestInfo)
+	return info
+}
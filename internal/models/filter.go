@@ -2,9 +2,68 @@ package models
 
 import "time"
 
+// способы сопоставления названий групп и песен
+const (
+	// точное совпадение
+	MatchExact = "exact"
+	// совпадение без учета регистра
+	MatchInsensitive = "icase"
+	// начало названия, без учета регистра
+	MatchPrefix = "prefix"
+	// триграммное сходство (pg_trgm), результаты упорядочены по сходству
+	MatchFuzzy = "fuzzy"
+)
+
+var MatchModes = []string{MatchExact, MatchInsensitive, MatchPrefix, MatchFuzzy}
+
+// способы сопоставления списков тегов и жанров
+const (
+	// песня должна иметь все перечисленные значения
+	SetMatchAll = "all"
+	// песня должна иметь хотя бы одно из перечисленных значений
+	SetMatchAny = "any"
+)
+
+var SetMatchModes = []string{SetMatchAll, SetMatchAny}
+
 type Filter struct {
-	Group          *string
-	Song           *string
+	Group *string
+	Song  *string
+	// любой из исполнителей песни (основной, приглашенный или автор ремикса)
+	Artist         *string
 	ReleasedBefore *time.Time
 	ReleasedAfter  *time.Time
+	// способ сопоставления Group, Song и Artist, пустая строка - точное совпадение
+	Match string
+	// теги, которые должны быть у песни, и теги, которых быть не должно
+	Tags        []string
+	ExcludeTags []string
+	// способ сопоставления Tags: all (по умолчанию) или any
+	TagMatch string
+	// жанры с учетом поджанров: песня с жанром grunge подходит под жанр rock
+	Genres        []string
+	ExcludeGenres []string
+	// способ сопоставления Genres: all (по умолчанию) или any
+	GenreMatch string
+	// границы длительности в секундах
+	MinDuration *int
+	MaxDuration *int
+	// границы темпа
+	MinBPM *int
+	MaxBPM *int
+	// код языка ISO 639-1
+	Language *string
+	Explicit *bool
+	Key      *string
+	ISRC     *string
+	// ключи сортировки в порядке приоритета
+	Sort []SortKey
+}
+
+// фильтры для получения текста песни
+type TextFilter struct {
+	// тип секции (verse, chorus, ...)
+	Section *string
+	// исключить повторяющиеся куплеты
+	Collapse bool
 }
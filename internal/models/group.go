@@ -0,0 +1,14 @@
+package models
+
+import "github.com/google/uuid"
+
+type Group struct {
+	ID        uuid.UUID `db:"id"`
+	GroupName string    `db:"group_name"`
+}
+
+// группа с количеством песен
+type GroupWithDetail struct {
+	Group
+	SongCount int `db:"song_count"`
+}
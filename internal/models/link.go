@@ -0,0 +1,49 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// типы ссылок на песню
+const (
+	// основная ссылка, она же SongDetail.Link
+	LinkPrimary   = "primary"
+	LinkYoutube   = "youtube"
+	LinkStreaming = "streaming"
+	// источник текста песни
+	LinkLyrics   = "lyrics"
+	LinkPurchase = "purchase"
+)
+
+var LinkTypes = []string{LinkPrimary, LinkYoutube, LinkStreaming, LinkLyrics, LinkPurchase}
+
+type SongLink struct {
+	ID   uuid.UUID `db:"id"`
+	URL  string    `db:"url"`
+	Type string    `db:"link_type"`
+	// код страны ISO 3166-1, nil - ссылка доступна везде
+	Region *string `db:"region"`
+	LinkCheck
+}
+
+// результат последней проверки ссылки
+type LinkCheck struct {
+	// время проверки, nil - ссылка еще не проверялась
+	CheckedAt *time.Time `db:"checked_at"`
+	// код ответа, nil - ответ не получен
+	StatusCode *int `db:"status_code"`
+	// адрес, на который ведет ссылка после перенаправлений, nil - перенаправлений не было
+	RedirectURL *string `db:"redirect_url"`
+	// ошибка запроса (таймаут, DNS и т.д.)
+	Error  *string `db:"check_error"`
+	Broken bool    `db:"broken"`
+}
+
+// нерабочая ссылка вместе с песней, к которой она относится
+type BrokenLink struct {
+	SongLink
+	SongID uuid.UUID
+	Song
+}
@@ -0,0 +1,167 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var ErrBadMetadata = errors.New("invalid song metadata")
+
+// границы допустимых значений метаданных
+const (
+	// сутки
+	MaxDuration = 24 * 60 * 60
+	MinBPM      = 1
+	MaxBPM      = 999
+)
+
+var (
+	// ISRC: код страны, код регистранта, год и номер записи (CC-XXX-YY-NNNNN), дефисы необязательны
+	isrcRe = regexp.MustCompile(`^[A-Z]{2}[A-Z0-9]{3}[0-9]{7}$`)
+	// код языка ISO 639-1
+	languageRe = regexp.MustCompile(`^[a-z]{2}$`)
+	// тональность: основная нота, необязательный знак альтерации и m для минора (C, F#, Bbm)
+	keyRe = regexp.MustCompile(`^[A-G][#b]?m?$`)
+)
+
+// дополнительные сведения о песне, nil - неизвестно
+type SongMeta struct {
+	// длительность в секундах
+	Duration *int `db:"duration_sec"`
+	// международный стандартный код записи
+	ISRC *string `db:"isrc"`
+	// язык текста (ISO 639-1)
+	Language *string `db:"language"`
+	// ненормативная лексика
+	Explicit bool `db:"explicit"`
+	BPM      *int `db:"bpm"`
+	// тональность
+	Key *string `db:"musical_key"`
+}
+
+// частичное обновление метаданных, nil-поля остаются без изменений
+type SongMetaPatch struct {
+	Duration *int
+	ISRC     *string
+	Language *string
+	Explicit *bool
+	BPM      *int
+	Key      *string
+}
+
+func (sm SongMetaPatch) IsEmpty() bool {
+	return sm == SongMetaPatch{}
+}
+
+// Проверяет метаданные и приводит их к виду, в котором они хранятся
+func (sm SongMeta) Normalize() (SongMeta, error) {
+	var err error
+
+	if err = ValidateDuration(sm.Duration); err != nil {
+		return SongMeta{}, err
+	}
+	if err = ValidateBPM(sm.BPM); err != nil {
+		return SongMeta{}, err
+	}
+	if sm.ISRC, err = NormalizeISRC(sm.ISRC); err != nil {
+		return SongMeta{}, err
+	}
+	if sm.Language, err = NormalizeLanguage(sm.Language); err != nil {
+		return SongMeta{}, err
+	}
+	if sm.Key, err = NormalizeKey(sm.Key); err != nil {
+		return SongMeta{}, err
+	}
+
+	return sm, nil
+}
+
+func (sm SongMetaPatch) Normalize() (SongMetaPatch, error) {
+	meta, err := SongMeta{
+		Duration: sm.Duration,
+		ISRC:     sm.ISRC,
+		Language: sm.Language,
+		BPM:      sm.BPM,
+		Key:      sm.Key,
+	}.Normalize()
+	if err != nil {
+		return SongMetaPatch{}, err
+	}
+
+	sm.ISRC = meta.ISRC
+	sm.Language = meta.Language
+	sm.Key = meta.Key
+
+	return sm, nil
+}
+
+func ValidateDuration(duration *int) error {
+	if duration != nil && (*duration <= 0 || *duration > MaxDuration) {
+		return fmt.Errorf("%w: duration should be between 1 and %v seconds", ErrBadMetadata, MaxDuration)
+	}
+	return nil
+}
+
+func ValidateBPM(bpm *int) error {
+	if bpm != nil && (*bpm < MinBPM || *bpm > MaxBPM) {
+		return fmt.Errorf("%w: bpm should be between %v and %v", ErrBadMetadata, MinBPM, MaxBPM)
+	}
+	return nil
+}
+
+// Приводит ISRC к верхнему регистру без дефисов: us-rc1-76-07839 -> USRC17607839
+func NormalizeISRC(isrc *string) (*string, error) {
+	if isrc == nil {
+		return nil, nil
+	}
+
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(*isrc), "-", ""))
+	if !isrcRe.MatchString(normalized) {
+		return nil, fmt.Errorf("%w: isrc should look like CC-XXX-YY-NNNNN", ErrBadMetadata)
+	}
+
+	return &normalized, nil
+}
+
+// Приводит код языка к нижнему регистру: EN -> en
+func NormalizeLanguage(language *string) (*string, error) {
+	if language == nil {
+		return nil, nil
+	}
+
+	normalized := strings.ToLower(strings.TrimSpace(*language))
+	if !languageRe.MatchString(normalized) {
+		return nil, fmt.Errorf("%w: language should be a two-letter ISO 639-1 code", ErrBadMetadata)
+	}
+
+	return &normalized, nil
+}
+
+// Приводит тональность к краткой записи: "a minor" -> Am, "f# major" -> F#
+func NormalizeKey(key *string) (*string, error) {
+	if key == nil {
+		return nil, nil
+	}
+
+	normalized := strings.TrimSpace(*key)
+	lower := strings.ToLower(normalized)
+	switch {
+	case strings.HasSuffix(lower, " minor"):
+		normalized = normalized[:len(normalized)-len(" minor")] + "m"
+	case strings.HasSuffix(lower, " major"):
+		normalized = normalized[:len(normalized)-len(" major")]
+	}
+
+	// основная нота пишется с заглавной буквы
+	if normalized != "" {
+		normalized = strings.ToUpper(normalized[:1]) + normalized[1:]
+	}
+
+	if !keyRe.MatchString(normalized) {
+		return nil, fmt.Errorf("%w: key should look like C, F#, Bb or Am", ErrBadMetadata)
+	}
+
+	return &normalized, nil
+}
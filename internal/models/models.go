@@ -3,6 +3,10 @@ package models
 import (
 	"strings"
 	"time"
+
+	"github.com/cutlery47/music-storage/internal/artists"
+	"github.com/cutlery47/music-storage/internal/lyrics"
+	"github.com/google/uuid"
 )
 
 type Song struct {
@@ -11,37 +15,196 @@ type Song struct {
 }
 
 type SongDetail struct {
-	ReleaseDate time.Time `db:"released_at"`
-	Link        string    `db:"link"`
+	// дата релиза; если не задана явно, берется из альбома, nil - неизвестна
+	ReleaseDate *time.Time `db:"released_at"`
+	Link        string     `db:"link"`
+	SongMeta
 }
 
 type SongWithDetail struct {
+	ID uuid.UUID `db:"id"`
 	Song
 	SongDetail
+	// все исполнители песни, основной - первый
+	Artists []Artist
 }
 
-// песня с необработанным текстом
+// песня с необработанным текстом.
+// Название группы может содержать приглашенных исполнителей ("A feat. B")
 type SongWithDetailPlain struct {
 	Song
 	SongDetail
-	Text string
+	// явно указанные исполнители помимо основного
+	Credits []Credit
+	Text    string
 }
 
-// песня с обработанным текстом
+// песня с обработанным текстом и разобранными исполнителями
 type SongWithDetailSplit struct {
 	Song
 	SongDetail
-	Verses []string
+	// исполнители помимо основного
+	Credits []Credit
+	Verses  []Verse
+}
+
+// куплет песни
+type Verse struct {
+	Num     int    `db:"verse_num"`
+	Section string `db:"section"`
+	Text    string `db:"verse"`
+	// номер куплета, который повторяет данный
+	RepeatOf *int `db:"repeat_of"`
 }
 
-// преобразование текста
-// TODO: придумать более sophisticated подход
+// преобразование текста: разбиение на куплеты, выделение приглашенных исполнителей из названия группы
 func (sp SongWithDetailPlain) Split() SongWithDetailSplit {
-	splitText := strings.Split(sp.Text, "\n")
+	song := sp.Song
+
+	primary, featured := artists.Parse(song.GroupName)
+	song.GroupName = primary
 
 	return SongWithDetailSplit{
-		Song:       sp.Song,
+		Song:       song,
 		SongDetail: sp.SongDetail,
-		Verses:     splitText,
+		Credits:    mergeCredits(primary, featured, sp.Credits),
+		Verses:     toVerses(lyrics.Parse(sp.Text)),
+	}
+}
+
+// Объединяет приглашенных исполнителей из названия группы с явно указанными, убирая повторы
+func mergeCredits(primary string, featured []string, explicit []Credit) []Credit {
+	credits := make([]Credit, 0, len(featured)+len(explicit))
+	for _, name := range featured {
+		credits = append(credits, Credit{Name: name, Role: RoleFeatured})
+	}
+	credits = append(credits, explicit...)
+
+	merged := make([]Credit, 0, len(credits))
+	seen := map[Credit]bool{}
+
+	for _, credit := range credits {
+		key := Credit{Name: strings.ToLower(credit.Name), Role: credit.Role}
+		// основной исполнитель не может быть приглашенным в собственную песню
+		if seen[key] || (credit.Role == RoleFeatured && strings.EqualFold(credit.Name, primary)) {
+			continue
+		}
+		seen[key] = true
+		merged = append(merged, credit)
 	}
+
+	return merged
+}
+
+func toVerses(stanzas []lyrics.Stanza) []Verse {
+	verses := make([]Verse, 0, len(stanzas))
+	for i, stanza := range stanzas {
+		verse := Verse{
+			Num:     i + 1,
+			Section: stanza.Section,
+			Text:    stanza.Text,
+		}
+		if stanza.RepeatOf != 0 {
+			repeatOf := stanza.RepeatOf
+			verse.RepeatOf = &repeatOf
+		}
+		verses = append(verses, verse)
+	}
+
+	return verses
+}
+
+// частичное обновление песни с необработанным текстом,
+// nil-поля остаются без изменений
+type SongPatchPlain struct {
+	GroupName   *string
+	SongName    *string
+	ReleaseDate *time.Time
+	Link        *string
+	Text        *string
+	SongMetaPatch
+}
+
+// частичное обновление песни с обработанным текстом,
+// nil-поля (в том числе Verses) остаются без изменений.
+// При смене группы приглашенные исполнители заменяются на Featured
+type SongPatchSplit struct {
+	GroupName   *string
+	Featured    []Credit
+	SongName    *string
+	ReleaseDate *time.Time
+	Link        *string
+	Verses      []Verse
+	SongMetaPatch
+}
+
+func (pp SongPatchPlain) Split() SongPatchSplit {
+	patch := SongPatchSplit{
+		SongName:      pp.SongName,
+		ReleaseDate:   pp.ReleaseDate,
+		Link:          pp.Link,
+		SongMetaPatch: pp.SongMetaPatch,
+	}
+
+	if pp.GroupName != nil {
+		primary, featured := artists.Parse(*pp.GroupName)
+		patch.GroupName = &primary
+		patch.Featured = mergeCredits(primary, featured, nil)
+	}
+
+	if pp.Text != nil {
+		patch.Verses = toVerses(lyrics.Parse(*pp.Text))
+	}
+
+	return patch
+}
+
+// страница текста песни
+type SongText struct {
+	Verses []Verse
+	// общее количество куплетов, подходящих под фильтры
+	Total int
+	// курсоры соседних страниц (при пагинации по курсору)
+	NextCursor *string
+	PrevCursor *string
+}
+
+// страница песен
+type SongsPage struct {
+	Songs []SongWithDetail
+	// общее количество песен, подходящих под фильтры
+	Total int
+	// курсоры соседних страниц (при пагинации по курсору), nil - страницы нет
+	NextCursor *string
+	PrevCursor *string
+}
+
+// Склеивает куплеты страницы в текст. При labels = true перед куплетами выводятся метки секций
+func (st SongText) Plain(labels bool) string {
+	stanzas := make([]string, 0, len(st.Verses))
+	for _, verse := range st.Verses {
+		if labels {
+			stanzas = append(stanzas, lyrics.Label(verse.Section)+"\n"+verse.Text)
+			continue
+		}
+		stanzas = append(stanzas, verse.Text)
+	}
+
+	return lyrics.Join(stanzas)
+}
+
+// результат полнотекстового поиска: песня и наиболее релевантный куплет
+type SearchResult struct {
+	SongWithDetail
+	VerseNum int `db:"verse_num"`
+	// куплет с выделенными совпадениями
+	Highlight string
+	Rank      float64
+}
+
+// страница результатов полнотекстового поиска
+type SearchPage struct {
+	Results []SearchResult
+	// общее количество найденных песен
+	Total int
 }
@@ -0,0 +1,37 @@
+package models
+
+import "github.com/google/uuid"
+
+// роли людей, участвовавших в создании песни
+const (
+	CreditLyricist = "lyricist"
+	CreditComposer = "composer"
+	CreditProducer = "producer"
+)
+
+var CreditRoles = []string{CreditLyricist, CreditComposer, CreditProducer}
+
+// автор или продюсер
+type Person struct {
+	ID   uuid.UUID `db:"id"`
+	Name string    `db:"full_name"`
+}
+
+// участие человека в создании песни
+type SongCredit struct {
+	Person
+	Role string `db:"role"`
+}
+
+// песня, в создании которой участвовал человек
+type PersonSong struct {
+	SongWithDetail
+	// роли человека в песне
+	Roles []string
+}
+
+// информация о песне вместе с авторами и продюсерами
+type SongDetailWithCredits struct {
+	SongDetail
+	Credits []SongCredit
+}
@@ -0,0 +1,150 @@
+package models
+
+import (
+	"reflect"
+	"time"
+)
+
+// виды изменения куплета между ревизиями
+const (
+	VerseUnchanged = "unchanged"
+	VerseAdded     = "added"
+	VerseRemoved   = "removed"
+	VerseChanged   = "changed"
+)
+
+// ревизия песни без текста
+type Revision struct {
+	Num int `db:"revision_num"`
+	Song
+	// автор изменения, nil - неизвестен
+	Author    *string   `db:"author"`
+	CreatedAt time.Time `db:"created_at"`
+	// номер ревизии, из которой восстановлена данная
+	RestoredFrom *int `db:"restored_from"`
+}
+
+// ревизия песни с деталями и полным текстом.
+// Дата релиза - указанная явно, без даты альбома
+type RevisionWithDetail struct {
+	Revision
+	SongDetail
+	Verses []Verse
+}
+
+// страница ревизий
+type RevisionsPage struct {
+	Revisions []Revision
+	Total     int
+}
+
+// изменение поля песни между ревизиями
+type FieldChange struct {
+	Field string
+	From  interface{}
+	To    interface{}
+}
+
+// изменение куплета между ревизиями; From - куплет в старой ревизии, To - в новой
+type VerseChange struct {
+	Op   string
+	From *Verse
+	To   *Verse
+}
+
+type RevisionDiff struct {
+	From   int
+	To     int
+	Fields []FieldChange
+	Verses []VerseChange
+}
+
+// Сравнивает две ревизии: поля песни попарно, текст - по куплетам
+func Diff(from, to RevisionWithDetail) RevisionDiff {
+	diff := RevisionDiff{
+		From:   from.Num,
+		To:     to.Num,
+		Fields: []FieldChange{},
+		Verses: diffVerses(from.Verses, to.Verses),
+	}
+
+	for _, field := range []struct {
+		name     string
+		from, to interface{}
+	}{
+		{"group", from.GroupName, to.GroupName},
+		{"song", from.SongName, to.SongName},
+		{"releaseDate", from.ReleaseDate, to.ReleaseDate},
+		{"link", from.Link, to.Link},
+		{"duration", from.Duration, to.Duration},
+		{"isrc", from.ISRC, to.ISRC},
+		{"language", from.Language, to.Language},
+		{"explicit", from.Explicit, to.Explicit},
+		{"bpm", from.BPM, to.BPM},
+		{"key", from.Key, to.Key},
+	} {
+		// указатели сравниваются по значениям
+		if !reflect.DeepEqual(field.from, field.to) {
+			diff.Fields = append(diff.Fields, FieldChange{Field: field.name, From: field.from, To: field.to})
+		}
+	}
+
+	return diff
+}
+
+// Сравнивает куплеты по наибольшей общей подпоследовательности.
+// Подряд идущие удаленные и добавленные куплеты объединяются попарно в измененные
+func diffVerses(from, to []Verse) []VerseChange {
+	same := func(a, b Verse) bool {
+		return a.Section == b.Section && a.Text == b.Text
+	}
+
+	// lcs[i][j] - длина общей подпоследовательности from[i:] и to[j:]
+	lcs := make([][]int, len(from)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(to)+1)
+	}
+	for i := len(from) - 1; i >= 0; i-- {
+		for j := len(to) - 1; j >= 0; j-- {
+			if same(from[i], to[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	changes := []VerseChange{}
+	var removed, added []VerseChange
+
+	// сбрасывает накопленные удаления и добавления, объединяя их попарно
+	flush := func() {
+		for len(removed) > 0 && len(added) > 0 {
+			changes = append(changes, VerseChange{Op: VerseChanged, From: removed[0].From, To: added[0].To})
+			removed, added = removed[1:], added[1:]
+		}
+		changes = append(changes, removed...)
+		changes = append(changes, added...)
+		removed, added = nil, nil
+	}
+
+	i, j := 0, 0
+	for i < len(from) || j < len(to) {
+		switch {
+		case i < len(from) && j < len(to) && same(from[i], to[j]):
+			flush()
+			changes = append(changes, VerseChange{Op: VerseUnchanged, From: &from[i], To: &to[j]})
+			i++
+			j++
+		case j < len(to) && (i == len(from) || lcs[i][j+1] >= lcs[i+1][j]):
+			added = append(added, VerseChange{Op: VerseAdded, To: &to[j]})
+			j++
+		default:
+			removed = append(removed, VerseChange{Op: VerseRemoved, From: &from[i]})
+			i++
+		}
+	}
+	flush()
+
+	return changes
+}
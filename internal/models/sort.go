@@ -0,0 +1,17 @@
+package models
+
+// поля, по которым можно сортировать песни
+const (
+	SortGroup       = "group"
+	SortSong        = "song"
+	SortReleaseDate = "releaseDate"
+	SortCreatedAt   = "createdAt"
+)
+
+var SortFields = []string{SortGroup, SortSong, SortReleaseDate, SortCreatedAt}
+
+// ключ сортировки
+type SortKey struct {
+	Field string
+	Desc  bool
+}
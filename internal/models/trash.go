@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// песня в корзине
+type DeletedSong struct {
+	ID uuid.UUID `db:"id"`
+	Song
+	DeletedAt time.Time `db:"deleted_at"`
+}
+
+// страница корзины
+type TrashPage struct {
+	Songs []DeletedSong
+	Total int
+}
@@ -0,0 +1,370 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+)
+
+// Добавление альбома. Группа создается при необходимости
+func (mr *MusicRepository) CreateAlbum(ctx context.Context, album models.Album, audit models.AuditEntry) (models.Album, error) {
+	query :=
+		`
+	INSERT INTO music_schema.albums
+	(group_id, title, released_at, album_type)
+	VALUES
+	($1, $2, $3, $4)
+	RETURNING id
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return models.Album{}, fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	groupId, err := mr.upsertGroup(ctx, tx, album.GroupName)
+	if err != nil {
+		return models.Album{}, err
+	}
+
+	if err := tx.QueryRowContext(ctx, query, groupId, album.Title, album.ReleaseDate, album.Type).Scan(&album.ID); err != nil {
+		if isUniqueViolation(err) {
+			return models.Album{}, ErrAlreadyExists
+		}
+		return models.Album{}, fmt.Errorf("tx.QueryRowContext: %v", err)
+	}
+
+	if err := mr.writeEntityAudit(ctx, tx, models.EntityAlbum, album.ID, audit, nil); err != nil {
+		return models.Album{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Album{}, fmt.Errorf("tx.Commit: %v", err)
+	}
+
+	return album, nil
+}
+
+// Получение списка альбомов, при groupId != nil - только альбомов группы
+func (mr *MusicRepository) ReadAlbums(ctx context.Context, limit, offset int, groupId *uuid.UUID) ([]models.Album, error) {
+	query :=
+		`
+	SELECT a.id, g.group_name, a.title, a.released_at, a.album_type
+	FROM
+	music_schema.albums AS a
+	JOIN
+	music_schema.groups AS g
+	ON a.group_id = g.id
+	WHERE
+	$1::uuid IS NULL OR g.id = $1
+	ORDER BY g.group_name, a.released_at NULLS LAST, a.title
+	LIMIT $2
+	OFFSET $3
+	`
+
+	rows, err := mr.db.QueryContext(ctx, query, groupId, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	albums := []models.Album{}
+	for rows.Next() {
+		album := models.Album{}
+		if err := rows.Scan(&album.ID, &album.GroupName, &album.Title, &album.ReleaseDate, &album.Type); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		albums = append(albums, album)
+	}
+
+	return albums, rows.Err()
+}
+
+func (mr *MusicRepository) ReadAlbum(ctx context.Context, id uuid.UUID) (models.AlbumWithDetail, error) {
+	query :=
+		`
+	SELECT a.id, g.group_name, a.title, a.released_at, a.album_type, COUNT(t.song_id)
+	FROM
+	music_schema.albums AS a
+	JOIN
+	music_schema.groups AS g
+	ON a.group_id = g.id
+	LEFT JOIN
+	music_schema.album_tracks AS t
+	ON t.album_id = a.id AND t.song_id IN (SELECT s.id FROM music_schema.songs AS s WHERE s.deleted_at IS NULL)
+	WHERE
+	a.id = $1
+	GROUP BY a.id, g.group_name
+	`
+
+	album := models.AlbumWithDetail{}
+	if err := mr.db.QueryRowContext(ctx, query, id).Scan(
+		&album.ID,
+		&album.GroupName,
+		&album.Title,
+		&album.ReleaseDate,
+		&album.Type,
+		&album.TrackCount,
+	); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.AlbumWithDetail{}, ErrNotFound
+		}
+		return models.AlbumWithDetail{}, fmt.Errorf("row.Scan: %v", err)
+	}
+
+	return album, nil
+}
+
+// Треклист альбома в порядке дисков и номеров треков
+func (mr *MusicRepository) ReadAlbumTracks(ctx context.Context, id uuid.UUID) ([]models.Track, error) {
+	query := fmt.Sprintf(
+		`
+	SELECT t.disc_num, t.track_num, s.id, g.group_name, s.song_name, %v
+	FROM
+	music_schema.album_tracks AS t
+	JOIN
+	music_schema.songs AS s
+	ON t.song_id = s.id
+	JOIN
+	music_schema.groups AS g
+	ON s.group_id = g.id
+	JOIN
+	music_schema.songs_details_resolved AS sd
+	ON s.id = sd.song_id
+	WHERE
+	t.album_id = $1 AND s.deleted_at IS NULL
+	ORDER BY t.disc_num, t.track_num
+	`, detailColumns)
+
+	rows, err := mr.db.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	tracks := []models.Track{}
+	for rows.Next() {
+		track := models.Track{}
+		dest := append([]interface{}{&track.DiscNum, &track.TrackNum}, songDest(&track.SongWithDetail)...)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		tracks = append(tracks, track)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	// пустой треклист может означать как отсутствие треков, так и отсутствие самого альбома
+	if len(tracks) == 0 {
+		if _, err := mr.ReadAlbum(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]uuid.UUID, len(tracks))
+	for i, track := range tracks {
+		ids[i] = track.ID
+	}
+
+	artists, err := mr.readArtists(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range tracks {
+		tracks[i].Artists = artists[tracks[i].ID]
+	}
+
+	return tracks, nil
+}
+
+func (mr *MusicRepository) UpdateAlbum(ctx context.Context, id uuid.UUID, upd models.Album, audit models.AuditEntry) error {
+	query :=
+		`
+	UPDATE music_schema.albums
+	SET
+	group_id = $1,
+	title = $2,
+	released_at = $3,
+	album_type = $4
+	WHERE
+	id = $5
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	before, err := mr.lockAlbum(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	groupId, err := mr.upsertGroup(ctx, tx, upd.GroupName)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, query, groupId, upd.Title, upd.ReleaseDate, upd.Type, id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("res.RowsAffected: %v", err)
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	if err := mr.writeEntityAudit(ctx, tx, models.EntityAlbum, id, audit, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Удаление альбома. Песни альбома не удаляются
+func (mr *MusicRepository) DeleteAlbum(ctx context.Context, id uuid.UUID, audit models.AuditEntry) error {
+	query :=
+		`
+	DELETE FROM music_schema.albums
+	WHERE id = $1
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	before, err := mr.lockAlbum(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	if err := mr.writeEntityAudit(ctx, tx, models.EntityAlbum, id, audit, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Добавление песни в альбом или перемещение уже добавленной на другую позицию
+func (mr *MusicRepository) SetAlbumTrack(ctx context.Context, albumId, songId uuid.UUID, pos models.TrackPosition, audit models.AuditEntry) error {
+	query :=
+		`
+	INSERT INTO music_schema.album_tracks
+	(album_id, song_id, disc_num, track_num)
+	VALUES
+	($1, $2, $3, $4)
+	ON CONFLICT (album_id, song_id) DO UPDATE
+	SET disc_num = EXCLUDED.disc_num, track_num = EXCLUDED.track_num
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	before, err := mr.lockAlbum(ctx, tx, albumId)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, query, albumId, songId, pos.DiscNum, pos.TrackNum); err != nil {
+		// позиция уже занята другой песней
+		if isUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+		// песни не существует
+		if isForeignKeyViolation(err) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	if err := mr.writeEntityAudit(ctx, tx, models.EntityAlbum, albumId, audit, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (mr *MusicRepository) DeleteAlbumTrack(ctx context.Context, albumId, songId uuid.UUID, audit models.AuditEntry) error {
+	query :=
+		`
+	DELETE FROM music_schema.album_tracks
+	WHERE album_id = $1 AND song_id = $2
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	before, err := mr.lockAlbum(ctx, tx, albumId)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, query, albumId, songId)
+	if err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("res.RowsAffected: %v", err)
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	if err := mr.writeEntityAudit(ctx, tx, models.EntityAlbum, albumId, audit, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Блокирует альбом до конца транзакции и возвращает его состояние для журнала аудита
+func (mr *MusicRepository) lockAlbum(ctx context.Context, tx *sql.Tx, id uuid.UUID) ([]byte, error) {
+	query :=
+		`
+	SELECT a.id
+	FROM music_schema.albums AS a
+	WHERE a.id = $1
+	FOR UPDATE
+	`
+
+	if err := tx.QueryRowContext(ctx, query, id).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("tx.QueryRowContext: %v", err)
+	}
+
+	return mr.snapshot(ctx, tx, models.EntityAlbum, id)
+}
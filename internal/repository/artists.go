@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Добавляет исполнителей песни: основного (группу песни) и остальных из credits.
+// Группы исполнителей создаются при необходимости
+func (mr *MusicRepository) insertArtists(ctx context.Context, tx *sql.Tx, songId, primaryId uuid.UUID, credits []models.Credit) error {
+	query :=
+		`
+	INSERT INTO music_schema.song_artists
+	(song_id, group_id, role, position)
+	VALUES
+	($1, $2, $3, $4)
+	ON CONFLICT DO NOTHING
+	`
+
+	if _, err := tx.ExecContext(ctx, query, songId, primaryId, models.RolePrimary, 1); err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	for i, credit := range credits {
+		groupId, err := mr.upsertGroup(ctx, tx, credit.Name)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.ExecContext(ctx, query, songId, groupId, credit.Role, i+2); err != nil {
+			return fmt.Errorf("tx.ExecContext: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// Удаляет исполнителей песни с указанными ролями
+func (mr *MusicRepository) deleteArtists(ctx context.Context, tx *sql.Tx, songId uuid.UUID, roles ...string) error {
+	query :=
+		`
+	DELETE FROM music_schema.song_artists
+	WHERE song_id = $1 AND role = ANY($2::text[])
+	`
+
+	if _, err := tx.ExecContext(ctx, query, songId, pq.Array(roles)); err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	return nil
+}
+
+// Исполнители песен с указанными id: основной первым, затем приглашенные и авторы ремиксов
+func (mr *MusicRepository) readArtists(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID][]models.Artist, error) {
+	artists := map[uuid.UUID][]models.Artist{}
+	if len(ids) == 0 {
+		return artists, nil
+	}
+
+	query :=
+		`
+	SELECT sa.song_id, g.id, g.group_name, sa.role
+	FROM
+	music_schema.song_artists AS sa
+	JOIN
+	music_schema.groups AS g
+	ON sa.group_id = g.id
+	WHERE
+	sa.song_id = ANY($1::uuid[])
+	ORDER BY sa.song_id, array_position(ARRAY['primary', 'featured', 'remixer'], sa.role::text), sa.position
+	`
+
+	rawIds := make([]string, len(ids))
+	for i, id := range ids {
+		rawIds[i] = id.String()
+	}
+
+	rows, err := mr.db.QueryContext(ctx, query, pq.Array(rawIds))
+	if err != nil {
+		return nil, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var songId uuid.UUID
+		artist := models.Artist{}
+		if err := rows.Scan(&songId, &artist.ID, &artist.Name, &artist.Role); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		artists[songId] = append(artists[songId], artist)
+	}
+
+	return artists, rows.Err()
+}
+
+// Заполняет исполнителей у списка песен
+func (mr *MusicRepository) attachArtists(ctx context.Context, songs []models.SongWithDetail) error {
+	ids := make([]uuid.UUID, len(songs))
+	for i, song := range songs {
+		ids[i] = song.ID
+	}
+
+	artists, err := mr.readArtists(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	for i := range songs {
+		songs[i].Artists = artists[songs[i].ID]
+	}
+
+	return nil
+}
@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+)
+
+// Записи журнала аудита, начиная с новых
+func (mr *MusicRepository) ReadAudit(ctx context.Context, limit, offset int, filter models.AuditFilter) (models.AuditPage, error) {
+	var (
+		applied    []interface{}
+		conditions []string
+	)
+
+	for _, field := range []struct {
+		expr string
+		set  bool
+		val  interface{}
+	}{
+		{"a.actor = $%v", filter.Actor != nil, filter.Actor},
+		{"a.action = $%v", filter.Action != nil, filter.Action},
+		{"a.entity_type = $%v", filter.EntityType != nil, filter.EntityType},
+		{"a.entity_id = $%v", filter.EntityID != nil, filter.EntityID},
+		{"a.created_at >= $%v", filter.From != nil, filter.From},
+		{"a.created_at < $%v", filter.To != nil, filter.To},
+	} {
+		if field.set {
+			applied = append(applied, field.val)
+			conditions = append(conditions, fmt.Sprintf(field.expr, len(applied)))
+		}
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE\n\t" + strings.Join(conditions, "\n\tAND\n\t")
+	}
+
+	queryCount := fmt.Sprintf(
+		`
+	SELECT COUNT(*)
+	FROM music_schema.audit_log AS a
+	%v
+	`, where)
+
+	page := models.AuditPage{Entries: []models.AuditEntry{}}
+	if err := mr.db.QueryRowContext(ctx, queryCount, applied...).Scan(&page.Total); err != nil {
+		return models.AuditPage{}, fmt.Errorf("mr.db.QueryRowContext: %v", err)
+	}
+
+	applied = append(applied, limit, offset)
+	query := fmt.Sprintf(
+		`
+	SELECT a.id, a.actor, a.action, a.entity_type, a.entity_id, a.before, a.after, a.request_id, a.ip, a.created_at
+	FROM
+	music_schema.audit_log AS a
+	%v
+	ORDER BY a.created_at DESC, a.id
+	LIMIT $%v
+	OFFSET $%v
+	`, where, len(applied)-1, len(applied))
+
+	rows, err := mr.db.QueryContext(ctx, query, applied...)
+	if err != nil {
+		return models.AuditPage{}, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		entry := models.AuditEntry{}
+		// json.RawMessage не сканируется из NULL, поэтому читаем в []byte
+		var before, after []byte
+		if err := rows.Scan(
+			&entry.ID,
+			&entry.Actor,
+			&entry.Action,
+			&entry.EntityType,
+			&entry.EntityID,
+			&before,
+			&after,
+			&entry.RequestID,
+			&entry.IP,
+			&entry.CreatedAt,
+		); err != nil {
+			return models.AuditPage{}, fmt.Errorf("rows.Scan: %v", err)
+		}
+		entry.Before, entry.After = before, after
+		page.Entries = append(page.Entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.AuditPage{}, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	return page, nil
+}
+
+// Дополняет запись аудита состоянием песни после изменения и сохраняет её в транзакции изменения.
+// before - состояние до изменения, полученное snapshotSong, nil - песни не было
+func (mr *MusicRepository) writeAudit(ctx context.Context, tx *sql.Tx, songId uuid.UUID, entry models.AuditEntry, before []byte) error {
+	return mr.writeEntityAudit(ctx, tx, models.EntitySong, songId, entry, before)
+}
+
+// Дополняет запись аудита состоянием сущности после изменения и сохраняет её в транзакции изменения.
+// before - состояние до изменения, полученное snapshot, nil - сущности не было
+func (mr *MusicRepository) writeEntityAudit(ctx context.Context, tx *sql.Tx, entityType string, id uuid.UUID, entry models.AuditEntry, before []byte) error {
+	query :=
+		`
+	INSERT INTO music_schema.audit_log
+	(actor, action, entity_type, entity_id, before, after, request_id, ip)
+	VALUES
+	($1::text, $2, $3, $4, $5::jsonb, $6::jsonb, $7::text, $8::text)
+	`
+
+	after, err := mr.snapshot(ctx, tx, entityType, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		query,
+		entry.Actor,
+		entry.Action,
+		entityType,
+		id,
+		nullJSON(before),
+		nullJSON(after),
+		entry.RequestID,
+		entry.IP,
+	); err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	return nil
+}
+
+// Запросы состояния сущностей в формате JSON для журнала аудита
+var snapshotQueries = map[string]string{
+	// детали, ссылки, участники, жанры, теги, полный текст и время удаления
+	models.EntitySong: `
+	SELECT json_build_object(
+		'group', g.group_name,
+		'song', s.song_name,
+		'releaseDate', sd.released_at,
+		'link', COALESCE((SELECT l.url::text FROM music_schema.song_links AS l WHERE l.song_id = s.id AND l.link_type = 'primary'), ''),
+		'duration', sd.duration_sec,
+		'isrc', COALESCE(sd.isrc, sd.deleted_isrc),
+		'language', sd.language,
+		'explicit', sd.explicit,
+		'bpm', sd.bpm,
+		'key', sd.musical_key,
+		'text', (SELECT string_agg(sv.verse, E'\n\n' ORDER BY sv.verse_num) FROM music_schema.songs_verses AS sv WHERE sv.song_id = s.id),
+		'links', COALESCE((
+			SELECT json_agg(json_build_object('type', l.link_type, 'url', l.url, 'region', l.region) ORDER BY l.link_type, l.region NULLS FIRST, l.url)
+			FROM music_schema.song_links AS l
+			WHERE l.song_id = s.id AND l.link_type <> 'primary'
+		), '[]'::json),
+		'credits', COALESCE((
+			SELECT json_agg(json_build_object('name', p.full_name, 'role', sc.role) ORDER BY sc.role, p.full_name)
+			FROM music_schema.song_credits AS sc
+			JOIN music_schema.people AS p
+			ON p.id = sc.person_id
+			WHERE sc.song_id = s.id
+		), '[]'::json),
+		'genres', COALESCE((
+			SELECT json_agg(gn.name ORDER BY gn.name)
+			FROM music_schema.song_genres AS sg
+			JOIN music_schema.genres AS gn
+			ON gn.id = sg.genre_id
+			WHERE sg.song_id = s.id
+		), '[]'::json),
+		'tags', COALESCE((SELECT json_agg(st.tag ORDER BY st.tag) FROM music_schema.song_tags AS st WHERE st.song_id = s.id), '[]'::json),
+		'deletedAt', s.deleted_at
+	)
+	FROM
+	music_schema.songs AS s
+	JOIN
+	music_schema.groups AS g
+	ON g.id = s.group_id
+	JOIN
+	music_schema.songs_details AS sd
+	ON sd.song_id = s.id
+	WHERE
+	s.id = $1
+	`,
+	// детали альбома и треклист
+	models.EntityAlbum: `
+	SELECT json_build_object(
+		'group', g.group_name,
+		'title', a.title,
+		'releaseDate', a.released_at,
+		'type', a.album_type,
+		'tracks', COALESCE((
+			SELECT json_agg(json_build_object('song', t.song_id, 'disc', t.disc_num, 'track', t.track_num) ORDER BY t.disc_num, t.track_num)
+			FROM music_schema.album_tracks AS t
+			WHERE t.album_id = a.id
+		), '[]'::json)
+	)
+	FROM
+	music_schema.albums AS a
+	JOIN
+	music_schema.groups AS g
+	ON g.id = a.group_id
+	WHERE
+	a.id = $1
+	`,
+	models.EntityGroup: `
+	SELECT json_build_object('name', g.group_name)
+	FROM music_schema.groups AS g
+	WHERE g.id = $1
+	`,
+	models.EntityPerson: `
+	SELECT json_build_object('name', p.full_name)
+	FROM music_schema.people AS p
+	WHERE p.id = $1
+	`,
+	models.EntityGenre: `
+	SELECT json_build_object('name', gn.name, 'parent', p.name)
+	FROM
+	music_schema.genres AS gn
+	LEFT JOIN
+	music_schema.genres AS p
+	ON gn.parent_id = p.id
+	WHERE
+	gn.id = $1
+	`,
+}
+
+// Состояние песни в формате JSON. nil - песни нет
+func (mr *MusicRepository) snapshotSong(ctx context.Context, tx *sql.Tx, songId uuid.UUID) ([]byte, error) {
+	return mr.snapshot(ctx, tx, models.EntitySong, songId)
+}
+
+// Состояние сущности в формате JSON. nil - сущности нет
+func (mr *MusicRepository) snapshot(ctx context.Context, tx *sql.Tx, entityType string, id uuid.UUID) ([]byte, error) {
+	query, ok := snapshotQueries[entityType]
+	if !ok {
+		return nil, fmt.Errorf("unknown entity type: %v", entityType)
+	}
+
+	var snapshot []byte
+	if err := tx.QueryRowContext(ctx, query, id).Scan(&snapshot); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("tx.QueryRowContext: %v", err)
+	}
+
+	return snapshot, nil
+}
+
+// NULL вместо пустого снимка
+func nullJSON(snapshot []byte) interface{} {
+	if len(snapshot) == 0 {
+		return nil
+	}
+	return string(snapshot)
+}
@@ -0,0 +1,412 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+// Добавление жанра. parent - название родительского жанра, nil - жанр верхнего уровня
+func (mr *MusicRepository) CreateGenre(ctx context.Context, name string, parent *string, audit models.AuditEntry) (models.Genre, error) {
+	query :=
+		`
+	INSERT INTO music_schema.genres
+	(name, parent_id)
+	SELECT $1, (SELECT p.id FROM music_schema.genres AS p WHERE p.name = $2::text)
+	WHERE $2::text IS NULL OR EXISTS (SELECT 1 FROM music_schema.genres AS p WHERE p.name = $2::text)
+	RETURNING id
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return models.Genre{}, fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	genre := models.Genre{Name: name, Parent: parent}
+	if err := tx.QueryRowContext(ctx, query, name, parent).Scan(&genre.ID); err != nil {
+		// родительского жанра не существует
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Genre{}, ErrUnknownGenre
+		}
+		if isUniqueViolation(err) {
+			return models.Genre{}, ErrAlreadyExists
+		}
+		return models.Genre{}, fmt.Errorf("row.Scan: %v", err)
+	}
+
+	if err := mr.writeEntityAudit(ctx, tx, models.EntityGenre, genre.ID, audit, nil); err != nil {
+		return models.Genre{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Genre{}, fmt.Errorf("tx.Commit: %v", err)
+	}
+
+	return genre, nil
+}
+
+// Все жанры в алфавитном порядке вместе с родительскими жанрами
+func (mr *MusicRepository) ReadGenres(ctx context.Context) ([]models.Genre, error) {
+	query :=
+		`
+	SELECT gn.id, gn.name, p.name
+	FROM
+	music_schema.genres AS gn
+	LEFT JOIN
+	music_schema.genres AS p
+	ON gn.parent_id = p.id
+	ORDER BY gn.name
+	`
+
+	rows, err := mr.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	genres := []models.Genre{}
+	for rows.Next() {
+		genre := models.Genre{}
+		if err := rows.Scan(&genre.ID, &genre.Name, &genre.Parent); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		genres = append(genres, genre)
+	}
+
+	return genres, rows.Err()
+}
+
+// Удаление жанра. Поджанры становятся жанрами верхнего уровня
+func (mr *MusicRepository) DeleteGenre(ctx context.Context, id uuid.UUID, audit models.AuditEntry) error {
+	query :=
+		`
+	DELETE FROM music_schema.genres
+	WHERE id = $1
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	before, err := mr.snapshot(ctx, tx, models.EntityGenre, id)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("res.RowsAffected: %v", err)
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	if err := mr.writeEntityAudit(ctx, tx, models.EntityGenre, id, audit, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (mr *MusicRepository) ReadSongGenres(ctx context.Context, songId uuid.UUID) ([]string, error) {
+	query := fmt.Sprintf(
+		`
+	SELECT gn.name
+	FROM
+	music_schema.song_genres AS sg
+	JOIN
+	music_schema.genres AS gn
+	ON sg.genre_id = gn.id
+	WHERE
+	sg.song_id = $1 AND %v
+	ORDER BY gn.name
+	`, liveSong)
+
+	return mr.readSongNames(ctx, query, songId)
+}
+
+func (mr *MusicRepository) ReadSongTags(ctx context.Context, songId uuid.UUID) ([]string, error) {
+	query := fmt.Sprintf(
+		`
+	SELECT st.tag
+	FROM
+	music_schema.song_tags AS st
+	WHERE
+	st.song_id = $1 AND %v
+	ORDER BY st.tag
+	`, liveSong)
+
+	return mr.readSongNames(ctx, query, songId)
+}
+
+// Выполняет запрос, возвращающий список названий (тегов или жанров) песни.
+// Пустой список может означать отсутствие песни, поэтому в этом случае проверяем её наличие
+func (mr *MusicRepository) readSongNames(ctx context.Context, query string, songId uuid.UUID) ([]string, error) {
+	rows, err := mr.db.QueryContext(ctx, query, songId)
+	if err != nil {
+		return nil, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	names := []string{}
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	if len(names) == 0 {
+		if _, err := mr.ReadDetail(ctx, songId); err != nil {
+			return nil, err
+		}
+	}
+
+	return names, nil
+}
+
+// Заменяет жанры песни. Все жанры должны существовать
+func (mr *MusicRepository) UpdateSongGenres(ctx context.Context, songId uuid.UUID, genres []string, audit models.AuditEntry) error {
+	queryDelete :=
+		`
+	DELETE FROM music_schema.song_genres
+	WHERE song_id = $1
+	`
+
+	queryInsert :=
+		`
+	INSERT INTO music_schema.song_genres
+	(song_id, genre_id)
+	SELECT $1, gn.id
+	FROM music_schema.genres AS gn
+	WHERE gn.name = ANY($2::text[])
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := mr.lockSong(ctx, tx, songId); err != nil {
+		return err
+	}
+
+	before, err := mr.snapshotSong(ctx, tx, songId)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, queryDelete, songId); err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	res, err := tx.ExecContext(ctx, queryInsert, songId, pq.Array(genres))
+	if err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("res.RowsAffected: %v", err)
+	}
+
+	// часть жанров не найдена
+	if int(affected) != len(genres) {
+		return ErrUnknownGenre
+	}
+
+	if err := mr.writeAudit(ctx, tx, songId, audit, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Заменяет теги песни
+func (mr *MusicRepository) UpdateSongTags(ctx context.Context, songId uuid.UUID, tags []string, audit models.AuditEntry) error {
+	queryDelete :=
+		`
+	DELETE FROM music_schema.song_tags
+	WHERE song_id = $1
+	`
+
+	queryInsert :=
+		`
+	INSERT INTO music_schema.song_tags
+	(song_id, tag)
+	SELECT $1, UNNEST($2::text[])
+	ON CONFLICT DO NOTHING
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := mr.lockSong(ctx, tx, songId); err != nil {
+		return err
+	}
+
+	before, err := mr.snapshotSong(ctx, tx, songId)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, queryDelete, songId); err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, queryInsert, songId, pq.Array(tags)); err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	if err := mr.writeAudit(ctx, tx, songId, audit, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Количество песен по тегам среди песен, подходящих под фильтры, по убыванию количества
+func (mr *MusicRepository) ReadTagCounts(ctx context.Context, limit int, filter models.Filter) ([]models.TagCount, error) {
+	var applied []interface{}
+
+	conditions, _ := mr.filterConditions(filter, &applied)
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE\n\t" + strings.Join(conditions, "\n\tAND\n\t")
+	}
+
+	applied = append(applied, limit)
+
+	query := fmt.Sprintf(
+		`
+	SELECT st.tag, COUNT(*)
+	FROM
+	music_schema.song_tags AS st
+	JOIN
+	music_schema.songs AS s
+	ON st.song_id = s.id
+	JOIN
+	music_schema.groups AS g
+	ON s.group_id = g.id
+	JOIN
+	music_schema.songs_details_resolved AS sd
+	ON s.id = sd.song_id
+	%v
+	GROUP BY st.tag
+	ORDER BY COUNT(*) DESC, st.tag
+	LIMIT $%v
+	`, where, len(applied))
+
+	rows, err := mr.db.QueryContext(ctx, query, applied...)
+	if err != nil {
+		return nil, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	counts := []models.TagCount{}
+	for rows.Next() {
+		count := models.TagCount{}
+		if err := rows.Scan(&count.Tag, &count.Count); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		counts = append(counts, count)
+	}
+
+	return counts, rows.Err()
+}
+
+// Блокирует песню до конца транзакции. Возвращает ErrNotFound, если песни нет
+func (mr *MusicRepository) lockSong(ctx context.Context, tx *sql.Tx, songId uuid.UUID) error {
+	query :=
+		`
+	SELECT s.id
+	FROM music_schema.songs AS s
+	WHERE s.id = $1 AND s.deleted_at IS NULL
+	FOR UPDATE
+	`
+
+	if err := tx.QueryRowContext(ctx, query, songId).Scan(&songId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("tx.QueryRowContext: %v", err)
+	}
+
+	return nil
+}
+
+// условие "у песни есть хотя бы один тег из массива $argNum"
+func tagExists(argNum int) string {
+	return fmt.Sprintf(
+		`EXISTS (
+		SELECT 1
+		FROM music_schema.song_tags AS st
+		WHERE st.song_id = s.id AND st.tag = ANY($%v::text[])
+	)`, argNum)
+}
+
+// условие "у песни есть хотя бы один жанр из массива $argNum или его поджанр"
+func genreExists(argNum int) string {
+	return fmt.Sprintf(
+		`EXISTS (
+		WITH RECURSIVE subtree AS (
+			SELECT gn.id FROM music_schema.genres AS gn WHERE gn.name = ANY($%v::text[])
+			UNION
+			SELECT gn.id FROM music_schema.genres AS gn JOIN subtree ON gn.parent_id = subtree.id
+		)
+		SELECT 1
+		FROM music_schema.song_genres AS sg
+		WHERE sg.song_id = s.id AND sg.genre_id IN (SELECT id FROM subtree)
+	)`, argNum)
+}
+
+// Условия включения и исключения значений (тегов или жанров).
+// В режиме all для каждого значения строится отдельное условие, в режиме any - одно общее.
+// Исключение работает как any: песня отбрасывается, если у нее есть хотя бы одно из значений
+func setConditions(include, exclude []string, match string, exists func(argNum int) string, applied *[]any) []string {
+	var conditions []string
+
+	if len(include) > 0 {
+		if match == models.SetMatchAny {
+			*applied = append(*applied, pq.Array(include))
+			conditions = append(conditions, exists(len(*applied)))
+		} else {
+			for _, val := range include {
+				*applied = append(*applied, pq.Array([]string{val}))
+				conditions = append(conditions, exists(len(*applied)))
+			}
+		}
+	}
+
+	if len(exclude) > 0 {
+		*applied = append(*applied, pq.Array(exclude))
+		conditions = append(conditions, "NOT "+exists(len(*applied)))
+	}
+
+	return conditions
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Содержимое курсора: значения ключей сортировки крайней строки страницы.
+// Значения хранятся в текстовом виде и приводятся postgres к типу колонки при сравнении
+type cursor struct {
+	Values []string `json:"v"`
+	// курсор на предыдущую страницу
+	Backward bool `json:"b,omitempty"`
+	// отпечаток сортировки, с которой был выдан курсор
+	Order string `json:"o"`
+}
+
+func (c cursor) encode() string {
+	raw, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeCursor(token string) (cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return cursor{}, ErrBadCursor
+	}
+
+	c := cursor{}
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return cursor{}, ErrBadCursor
+	}
+
+	return c, nil
+}
+
+// Ключи сортировки для keyset-пагинации.
+// Последний ключ должен быть уникальным, чтобы порядок был однозначным
+type keyset struct {
+	exprs []string
+	desc  []bool
+}
+
+func (k *keyset) add(expr string, desc bool) {
+	k.exprs = append(k.exprs, expr)
+	k.desc = append(k.desc, desc)
+}
+
+// отпечаток сортировки для проверки, что курсор выдан для того же запроса
+func (k keyset) fingerprint() string {
+	keys := make([]string, len(k.exprs))
+	for i, expr := range k.exprs {
+		keys[i] = fmt.Sprintf("%v %v", expr, k.desc[i])
+	}
+	return strings.Join(keys, ",")
+}
+
+// ORDER BY; при reverse = true направление всех ключей меняется на противоположное
+func (k keyset) orderBy(reverse bool) string {
+	keys := make([]string, len(k.exprs))
+	for i, expr := range k.exprs {
+		if k.desc[i] != reverse {
+			expr += " DESC"
+		}
+		keys[i] = expr
+	}
+	return "ORDER BY " + strings.Join(keys, ", ")
+}
+
+// значения ключей в текстовом виде для SELECT
+func (k keyset) selectValues() string {
+	vals := make([]string, len(k.exprs))
+	for i, expr := range k.exprs {
+		vals[i] = fmt.Sprintf("(%v)::text", expr)
+	}
+	return strings.Join(vals, ", ")
+}
+
+// Условие "строка идет после курсора" в порядке сортировки (или до него, если курсор обратный).
+// Направления ключей могут различаться, поэтому сравнение кортежей не подходит:
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ...
+func (k keyset) after(c cursor, applied *[]any) (string, error) {
+	if len(c.Values) != len(k.exprs) || c.Order != k.fingerprint() {
+		return "", ErrBadCursor
+	}
+
+	var alternatives []string
+	for i := range k.exprs {
+		var parts []string
+		for j := 0; j < i; j++ {
+			*applied = append(*applied, c.Values[j])
+			parts = append(parts, fmt.Sprintf("%v = $%v", k.exprs[j], len(*applied)))
+		}
+
+		op := ">"
+		if k.desc[i] != c.Backward {
+			op = "<"
+		}
+		*applied = append(*applied, c.Values[i])
+		parts = append(parts, fmt.Sprintf("%v %v $%v", k.exprs[i], op, len(*applied)))
+
+		alternatives = append(alternatives, "("+strings.Join(parts, " AND ")+")")
+	}
+
+	return "(" + strings.Join(alternatives, " OR ") + ")", nil
+}
+
+// Формирует страницу и курсоры соседних страниц.
+// items и keys - строки и значения их ключей в порядке выборки. Выборка делается с лимитом limit+1,
+// чтобы узнать, есть ли строки дальше; from - курсор, по которому пришел запрос (nil - первая страница)
+func paginate[T any](k keyset, items []T, keys [][]string, limit int, from *cursor) ([]T, *string, *string) {
+	// отрицательный limit не должен доходить сюда, но срез по нему паникует
+	limit = max(limit, 0)
+
+	more := len(items) > limit
+	if more {
+		items, keys = items[:limit], keys[:limit]
+	}
+
+	backward := from != nil && from.Backward
+	if backward {
+		// строки выбирались в обратном порядке
+		slices.Reverse(items)
+		slices.Reverse(keys)
+	}
+
+	if len(items) == 0 {
+		return items, nil, nil
+	}
+
+	var next, prev *string
+
+	// вперед: следующая страница есть, если выбрано больше limit строк,
+	// предыдущая - если запрос пришел по курсору. Назад - наоборот
+	if more || backward {
+		token := cursor{Values: keys[len(keys)-1], Order: k.fingerprint()}.encode()
+		next = &token
+	}
+	if (backward && more) || (!backward && from != nil) {
+		token := cursor{Values: keys[0], Backward: true, Order: k.fingerprint()}.encode()
+		prev = &token
+	}
+
+	return items, next, prev
+}
@@ -1,8 +1,29 @@
 package repository
 
-import "errors"
+import (
+	"errors"
+
+	"github.com/lib/pq"
+)
 
 var (
 	ErrNotFound      = errors.New("no data was found...")
 	ErrAlreadyExists = errors.New("data already exists...")
+	ErrBadLanguage   = errors.New("unsupported text search language...")
+	ErrBadCursor     = errors.New("invalid or expired pagination cursor...")
+	ErrUnknownGenre  = errors.New("genre doesn't exist...")
+	ErrPrimaryLink   = errors.New("primary link can't be removed, only replaced...")
+	ErrGroupNotEmpty = errors.New("group still has songs, including songs in the trash or featuring the group...")
 )
+
+// 23505 - unique_violation
+func isUniqueViolation(err error) bool {
+	var pqerr *pq.Error
+	return errors.As(err, &pqerr) && pqerr.Code == "23505"
+}
+
+// 23503 - foreign_key_violation
+func isForeignKeyViolation(err error) bool {
+	var pqerr *pq.Error
+	return errors.As(err, &pqerr) && pqerr.Code == "23503"
+}
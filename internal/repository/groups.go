@@ -0,0 +1,306 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+)
+
+func (mr *MusicRepository) CreateGroup(ctx context.Context, groupName string, audit models.AuditEntry) (models.Group, error) {
+	query :=
+		`
+	INSERT INTO music_schema.groups
+	(group_name)
+	VALUES
+	($1)
+	RETURNING id, group_name
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return models.Group{}, fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	group := models.Group{}
+	if err := tx.QueryRowContext(ctx, query, groupName).Scan(&group.ID, &group.GroupName); err != nil {
+		if isUniqueViolation(err) {
+			return models.Group{}, ErrAlreadyExists
+		}
+		return models.Group{}, fmt.Errorf("row.Scan: %v", err)
+	}
+
+	if err := mr.writeEntityAudit(ctx, tx, models.EntityGroup, group.ID, audit, nil); err != nil {
+		return models.Group{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Group{}, fmt.Errorf("tx.Commit: %v", err)
+	}
+
+	return group, nil
+}
+
+func (mr *MusicRepository) ReadGroups(ctx context.Context, limit, offset int) ([]models.Group, error) {
+	query :=
+		`
+	SELECT g.id, g.group_name
+	FROM
+	music_schema.groups AS g
+	ORDER BY g.group_name
+	LIMIT $1
+	OFFSET $2
+	`
+
+	rows, err := mr.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	groups := []models.Group{}
+	for rows.Next() {
+		group := models.Group{}
+		if err := rows.Scan(&group.ID, &group.GroupName); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		groups = append(groups, group)
+	}
+
+	return groups, rows.Err()
+}
+
+func (mr *MusicRepository) ReadGroup(ctx context.Context, id uuid.UUID) (models.GroupWithDetail, error) {
+	query :=
+		`
+	SELECT g.id, g.group_name, COUNT(s.id)
+	FROM
+	music_schema.groups AS g
+	LEFT JOIN
+	music_schema.songs AS s
+	ON
+	s.group_id = g.id AND s.deleted_at IS NULL
+	WHERE
+	g.id = $1
+	GROUP BY g.id
+	`
+
+	group := models.GroupWithDetail{}
+	if err := mr.db.QueryRowContext(ctx, query, id).Scan(&group.ID, &group.GroupName, &group.SongCount); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.GroupWithDetail{}, ErrNotFound
+		}
+		return models.GroupWithDetail{}, fmt.Errorf("row.Scan: %v", err)
+	}
+
+	return group, nil
+}
+
+func (mr *MusicRepository) ReadGroupSongs(ctx context.Context, id uuid.UUID, limit, offset int) ([]models.SongWithDetail, error) {
+	query := fmt.Sprintf(
+		`
+	SELECT s.id, g.group_name, s.song_name, %v
+	FROM
+	music_schema.songs AS s
+	JOIN
+	music_schema.groups AS g
+	ON s.group_id = g.id
+	JOIN
+	music_schema.songs_details_resolved AS sd
+	ON s.id = sd.song_id
+	WHERE
+	g.id = $1 AND s.deleted_at IS NULL
+	ORDER BY s.song_name
+	LIMIT $2
+	OFFSET $3
+	`, detailColumns)
+
+	rows, err := mr.db.QueryContext(ctx, query, id, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	songs := []models.SongWithDetail{}
+	for rows.Next() {
+		song := models.SongWithDetail{}
+		if err := rows.Scan(songDest(&song)...); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		songs = append(songs, song)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	// пустая выборка может означать как отсутствие песен, так и отсутствие самой группы
+	if len(songs) == 0 {
+		if _, err := mr.ReadGroup(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := mr.attachArtists(ctx, songs); err != nil {
+		return nil, err
+	}
+
+	return songs, nil
+}
+
+// Переименование группы. Песни ссылаются на группу по id, поэтому обновлять их не требуется,
+// но название группы хранится в ревизиях и журнале аудита, поэтому для каждой песни группы
+// записываются новая ревизия и запись аудита
+func (mr *MusicRepository) UpdateGroup(ctx context.Context, id uuid.UUID, groupName string, audit models.AuditEntry) error {
+	query :=
+		`
+	UPDATE music_schema.groups
+	SET
+	group_name = $1
+	WHERE
+	id = $2
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	songIds, err := mr.lockGroupSongs(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	before := make([][]byte, len(songIds))
+	for i, songId := range songIds {
+		if before[i], err = mr.snapshotSong(ctx, tx, songId); err != nil {
+			return err
+		}
+	}
+
+	res, err := tx.ExecContext(ctx, query, groupName, id)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("res.RowsAffected: %v", err)
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	for i, songId := range songIds {
+		if _, err := mr.insertRevision(ctx, tx, songId, nil); err != nil {
+			return err
+		}
+		if err := mr.writeAudit(ctx, tx, songId, audit, before[i]); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Удаление группы. Группу, у которой остались песни, в том числе в корзине или с её участием, удалить нельзя:
+// песни удалились бы вместе с ней, минуя корзину
+func (mr *MusicRepository) DeleteGroup(ctx context.Context, id uuid.UUID, audit models.AuditEntry) error {
+	// блокировка не дает одновременно добавить песню группы
+	queryLockGroup :=
+		`
+	SELECT g.id
+	FROM music_schema.groups AS g
+	WHERE g.id = $1
+	FOR UPDATE
+	`
+
+	queryHasSongs :=
+		`
+	SELECT
+	EXISTS (SELECT 1 FROM music_schema.songs AS s WHERE s.group_id = $1)
+	OR
+	EXISTS (SELECT 1 FROM music_schema.song_artists AS sa WHERE sa.group_id = $1)
+	`
+
+	query :=
+		`
+	DELETE FROM music_schema.groups
+	WHERE id = $1
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRowContext(ctx, queryLockGroup, id).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("tx.QueryRowContext: %v", err)
+	}
+
+	var hasSongs bool
+	if err := tx.QueryRowContext(ctx, queryHasSongs, id).Scan(&hasSongs); err != nil {
+		return fmt.Errorf("tx.QueryRowContext: %v", err)
+	}
+
+	if hasSongs {
+		return ErrGroupNotEmpty
+	}
+
+	before, err := mr.snapshot(ctx, tx, models.EntityGroup, id)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	if err := mr.writeEntityAudit(ctx, tx, models.EntityGroup, id, audit, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Блокирует песни группы, кроме песен из корзины, до конца транзакции и возвращает их id
+func (mr *MusicRepository) lockGroupSongs(ctx context.Context, tx *sql.Tx, groupId uuid.UUID) ([]uuid.UUID, error) {
+	query :=
+		`
+	SELECT s.id
+	FROM music_schema.songs AS s
+	WHERE s.group_id = $1 AND s.deleted_at IS NULL
+	ORDER BY s.id
+	FOR UPDATE
+	`
+
+	rows, err := tx.QueryContext(ctx, query, groupId)
+	if err != nil {
+		return nil, fmt.Errorf("tx.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
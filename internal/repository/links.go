@@ -0,0 +1,322 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+)
+
+// Ссылки песни: сначала основная, затем по типу и региону
+func (mr *MusicRepository) ReadLinks(ctx context.Context, songId uuid.UUID) ([]models.SongLink, error) {
+	query := fmt.Sprintf(
+		`
+	SELECT l.id, l.url, l.link_type, l.region, l.checked_at, l.status_code, l.redirect_url, l.check_error, l.broken
+	FROM
+	music_schema.song_links AS l
+	WHERE
+	l.song_id = $1 AND %v
+	ORDER BY l.link_type <> 'primary', l.link_type, l.region NULLS FIRST, l.created_at
+	`, liveSong)
+
+	rows, err := mr.db.QueryContext(ctx, query, songId)
+	if err != nil {
+		return nil, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	links := []models.SongLink{}
+	for rows.Next() {
+		link := models.SongLink{}
+		if err := rows.Scan(linkDest(&link)...); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		links = append(links, link)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	// пустой список может означать как отсутствие ссылок, так и отсутствие самой песни
+	if len(links) == 0 {
+		if _, err := mr.ReadDetail(ctx, songId); err != nil {
+			return nil, err
+		}
+	}
+
+	return links, nil
+}
+
+// Добавление ссылки. Основная ссылка у песни одна, поэтому новая основная ссылка заменяет старую.
+// Основная ссылка хранится в ревизиях, поэтому её замена записывается новой ревизией
+func (mr *MusicRepository) CreateLink(ctx context.Context, songId uuid.UUID, link models.SongLink, audit models.AuditEntry) (models.SongLink, error) {
+	query :=
+		`
+	INSERT INTO music_schema.song_links
+	(song_id, url, link_type, region)
+	VALUES
+	($1, $2, $3, $4)
+	RETURNING id
+	`
+
+	if link.Type == models.LinkPrimary {
+		query = fmt.Sprintf(
+			`
+	INSERT INTO music_schema.song_links
+	(song_id, url, link_type, region)
+	VALUES
+	($1, $2, $3, $4)
+	ON CONFLICT (song_id) WHERE link_type = 'primary' DO UPDATE
+	SET url = EXCLUDED.url, region = EXCLUDED.region, %v
+	RETURNING id
+	`, resetLinkCheck)
+	}
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return models.SongLink{}, fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := mr.lockSong(ctx, tx, songId); err != nil {
+		return models.SongLink{}, err
+	}
+
+	before, err := mr.snapshotSong(ctx, tx, songId)
+	if err != nil {
+		return models.SongLink{}, err
+	}
+
+	if err := tx.QueryRowContext(ctx, query, songId, link.URL, link.Type, link.Region).Scan(&link.ID); err != nil {
+		if isUniqueViolation(err) {
+			return models.SongLink{}, ErrAlreadyExists
+		}
+		return models.SongLink{}, fmt.Errorf("tx.QueryRowContext: %v", err)
+	}
+
+	if link.Type == models.LinkPrimary {
+		if _, err := mr.insertRevision(ctx, tx, songId, nil); err != nil {
+			return models.SongLink{}, err
+		}
+	}
+
+	if err := mr.writeAudit(ctx, tx, songId, audit, before); err != nil {
+		return models.SongLink{}, err
+	}
+
+	return link, tx.Commit()
+}
+
+// Удаление ссылки. Основную ссылку удалить нельзя, её можно только заменить
+func (mr *MusicRepository) DeleteLink(ctx context.Context, songId, linkId uuid.UUID, audit models.AuditEntry) error {
+	query :=
+		`
+	DELETE FROM music_schema.song_links
+	WHERE song_id = $1 AND id = $2 AND link_type <> 'primary'
+	`
+
+	queryIsPrimary :=
+		`
+	SELECT EXISTS (
+		SELECT 1
+		FROM music_schema.song_links AS l
+		WHERE l.song_id = $1 AND l.id = $2
+	)
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := mr.lockSong(ctx, tx, songId); err != nil {
+		return err
+	}
+
+	before, err := mr.snapshotSong(ctx, tx, songId)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, query, songId, linkId)
+	if err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("res.RowsAffected: %v", err)
+	}
+
+	if affected == 0 {
+		// ссылка не удалилась: её либо нет, либо она основная
+		var isPrimary bool
+		if err := tx.QueryRowContext(ctx, queryIsPrimary, songId, linkId).Scan(&isPrimary); err != nil {
+			return fmt.Errorf("row.Scan: %v", err)
+		}
+
+		if isPrimary {
+			return ErrPrimaryLink
+		}
+
+		return ErrNotFound
+	}
+
+	if err := mr.writeAudit(ctx, tx, songId, audit, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Записывает основную ссылку песни в рамках транзакции
+func (mr *MusicRepository) upsertPrimaryLink(ctx context.Context, tx *sql.Tx, songId uuid.UUID, url string) error {
+	query := fmt.Sprintf(
+		`
+	INSERT INTO music_schema.song_links
+	(song_id, url, link_type)
+	VALUES
+	($1, $2, 'primary')
+	ON CONFLICT (song_id) WHERE link_type = 'primary' DO UPDATE
+	SET url = EXCLUDED.url, %v
+	`, resetLinkCheck)
+
+	if _, err := tx.ExecContext(ctx, query, songId, url); err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	return nil
+}
+
+// Ссылки, которые не проверялись с checkedBefore, начиная с давно не проверявшихся
+func (mr *MusicRepository) ReadLinksToCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]models.SongLink, error) {
+	query :=
+		`
+	SELECT l.id, l.url, l.link_type, l.region, l.checked_at, l.status_code, l.redirect_url, l.check_error, l.broken
+	FROM
+	music_schema.song_links AS l
+	JOIN
+	music_schema.songs AS s
+	ON l.song_id = s.id
+	WHERE
+	s.deleted_at IS NULL AND (l.checked_at IS NULL OR l.checked_at < $1)
+	ORDER BY l.checked_at NULLS FIRST
+	LIMIT $2
+	`
+
+	rows, err := mr.db.QueryContext(ctx, query, checkedBefore, limit)
+	if err != nil {
+		return nil, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	links := []models.SongLink{}
+	for rows.Next() {
+		link := models.SongLink{}
+		if err := rows.Scan(linkDest(&link)...); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+// Сохраняет результат проверки ссылки. Если ссылка была удалена во время проверки, ничего не делает
+func (mr *MusicRepository) UpdateLinkCheck(ctx context.Context, id uuid.UUID, check models.LinkCheck) error {
+	query :=
+		`
+	UPDATE music_schema.song_links
+	SET
+	checked_at = $1,
+	status_code = $2,
+	redirect_url = $3,
+	check_error = $4,
+	broken = $5
+	WHERE
+	id = $6
+	`
+
+	if _, err := mr.db.ExecContext(
+		ctx,
+		query,
+		check.CheckedAt,
+		check.StatusCode,
+		check.RedirectURL,
+		check.Error,
+		check.Broken,
+		id,
+	); err != nil {
+		return fmt.Errorf("mr.db.ExecContext: %v", err)
+	}
+
+	return nil
+}
+
+// Нерабочие ссылки, начиная с недавно проверенных
+func (mr *MusicRepository) ReadBrokenLinks(ctx context.Context, limit, offset int) ([]models.BrokenLink, error) {
+	query :=
+		`
+	SELECT l.id, l.url, l.link_type, l.region, l.checked_at, l.status_code, l.redirect_url, l.check_error, l.broken,
+	s.id, g.group_name, s.song_name
+	FROM
+	music_schema.song_links AS l
+	JOIN
+	music_schema.songs AS s
+	ON l.song_id = s.id
+	JOIN
+	music_schema.groups AS g
+	ON s.group_id = g.id
+	WHERE
+	l.broken AND s.deleted_at IS NULL
+	ORDER BY l.checked_at DESC, l.id
+	LIMIT $1
+	OFFSET $2
+	`
+
+	rows, err := mr.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	links := []models.BrokenLink{}
+	for rows.Next() {
+		link := models.BrokenLink{}
+		dest := append(linkDest(&link.SongLink), &link.SongID, &link.GroupName, &link.SongName)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+// при смене адреса ссылки результаты прошлой проверки сбрасываются
+const resetLinkCheck = `
+	checked_at = CASE WHEN song_links.url = EXCLUDED.url THEN song_links.checked_at END,
+	status_code = CASE WHEN song_links.url = EXCLUDED.url THEN song_links.status_code END,
+	redirect_url = CASE WHEN song_links.url = EXCLUDED.url THEN song_links.redirect_url END,
+	check_error = CASE WHEN song_links.url = EXCLUDED.url THEN song_links.check_error END,
+	broken = song_links.broken AND song_links.url = EXCLUDED.url`
+
+// Получатели для столбцов "l.id, l.url, l.link_type, l.region" и результатов проверки
+func linkDest(link *models.SongLink) []interface{} {
+	return []interface{}{
+		&link.ID,
+		&link.URL,
+		&link.Type,
+		&link.Region,
+		&link.CheckedAt,
+		&link.StatusCode,
+		&link.RedirectURL,
+		&link.Error,
+		&link.Broken,
+	}
+}
@@ -0,0 +1,302 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+)
+
+func (mr *MusicRepository) CreatePerson(ctx context.Context, name string, audit models.AuditEntry) (models.Person, error) {
+	query :=
+		`
+	INSERT INTO music_schema.people
+	(full_name)
+	VALUES
+	($1)
+	RETURNING id, full_name
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return models.Person{}, fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	person := models.Person{}
+	if err := tx.QueryRowContext(ctx, query, name).Scan(&person.ID, &person.Name); err != nil {
+		if isUniqueViolation(err) {
+			return models.Person{}, ErrAlreadyExists
+		}
+		return models.Person{}, fmt.Errorf("row.Scan: %v", err)
+	}
+
+	if err := mr.writeEntityAudit(ctx, tx, models.EntityPerson, person.ID, audit, nil); err != nil {
+		return models.Person{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.Person{}, fmt.Errorf("tx.Commit: %v", err)
+	}
+
+	return person, nil
+}
+
+func (mr *MusicRepository) ReadPeople(ctx context.Context, limit, offset int) ([]models.Person, error) {
+	query :=
+		`
+	SELECT p.id, p.full_name
+	FROM
+	music_schema.people AS p
+	ORDER BY p.full_name
+	LIMIT $1
+	OFFSET $2
+	`
+
+	rows, err := mr.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	people := []models.Person{}
+	for rows.Next() {
+		person := models.Person{}
+		if err := rows.Scan(&person.ID, &person.Name); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		people = append(people, person)
+	}
+
+	return people, rows.Err()
+}
+
+func (mr *MusicRepository) ReadPerson(ctx context.Context, id uuid.UUID) (models.Person, error) {
+	query :=
+		`
+	SELECT p.id, p.full_name
+	FROM
+	music_schema.people AS p
+	WHERE
+	p.id = $1
+	`
+
+	person := models.Person{}
+	if err := mr.db.QueryRowContext(ctx, query, id).Scan(&person.ID, &person.Name); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.Person{}, ErrNotFound
+		}
+		return models.Person{}, fmt.Errorf("row.Scan: %v", err)
+	}
+
+	return person, nil
+}
+
+// Песни, в создании которых участвовал человек, вместе с его ролями
+func (mr *MusicRepository) ReadPersonSongs(ctx context.Context, id uuid.UUID, limit, offset int) ([]models.PersonSong, error) {
+	query := fmt.Sprintf(
+		`
+	SELECT s.id, g.group_name, s.song_name, %[1]v,
+	array_agg(sc.role::text ORDER BY array_position(ARRAY['lyricist', 'composer', 'producer'], sc.role::text))
+	FROM
+	music_schema.song_credits AS sc
+	JOIN
+	music_schema.songs AS s
+	ON sc.song_id = s.id
+	JOIN
+	music_schema.groups AS g
+	ON s.group_id = g.id
+	JOIN
+	music_schema.songs_details_resolved AS sd
+	ON s.id = sd.song_id
+	WHERE
+	sc.person_id = $1 AND s.deleted_at IS NULL
+	GROUP BY s.id, g.group_name, s.song_name, %[1]v
+	ORDER BY g.group_name, s.song_name
+	LIMIT $2
+	OFFSET $3
+	`, detailColumns)
+
+	rows, err := mr.db.QueryContext(ctx, query, id, limit, offset)
+	if err != nil {
+		return nil, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	songs := []models.PersonSong{}
+	for rows.Next() {
+		song := models.PersonSong{}
+		if err := rows.Scan(songDest(&song.SongWithDetail, pq.Array(&song.Roles))...); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		songs = append(songs, song)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	// пустая выборка может означать как отсутствие песен, так и отсутствие самого человека
+	if len(songs) == 0 {
+		if _, err := mr.ReadPerson(ctx, id); err != nil {
+			return nil, err
+		}
+	}
+
+	ids := make([]uuid.UUID, len(songs))
+	for i, song := range songs {
+		ids[i] = song.ID
+	}
+
+	artists, err := mr.readArtists(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range songs {
+		songs[i].Artists = artists[songs[i].ID]
+	}
+
+	return songs, nil
+}
+
+// Авторы и продюсеры песни
+func (mr *MusicRepository) ReadCredits(ctx context.Context, songId uuid.UUID) ([]models.SongCredit, error) {
+	query := fmt.Sprintf(
+		`
+	SELECT p.id, p.full_name, sc.role
+	FROM
+	music_schema.song_credits AS sc
+	JOIN
+	music_schema.people AS p
+	ON sc.person_id = p.id
+	WHERE
+	sc.song_id = $1 AND %v
+	ORDER BY array_position(ARRAY['lyricist', 'composer', 'producer'], sc.role::text), p.full_name
+	`, liveSong)
+
+	rows, err := mr.db.QueryContext(ctx, query, songId)
+	if err != nil {
+		return nil, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	credits := []models.SongCredit{}
+	for rows.Next() {
+		credit := models.SongCredit{}
+		if err := rows.Scan(&credit.ID, &credit.Name, &credit.Role); err != nil {
+			return nil, fmt.Errorf("rows.Scan: %v", err)
+		}
+		credits = append(credits, credit)
+	}
+
+	return credits, rows.Err()
+}
+
+// Добавляет человека в участники песни. Человек создается при необходимости
+func (mr *MusicRepository) CreateCredit(ctx context.Context, songId uuid.UUID, name, role string, audit models.AuditEntry) (models.SongCredit, error) {
+	// DO UPDATE вместо DO NOTHING, чтобы RETURNING вернул id уже существующего человека
+	queryUpsertPerson :=
+		`
+	INSERT INTO music_schema.people
+	(full_name)
+	VALUES
+	($1)
+	ON CONFLICT (full_name) DO UPDATE
+	SET full_name = EXCLUDED.full_name
+	RETURNING id, full_name
+	`
+
+	queryInsertCredit :=
+		`
+	INSERT INTO music_schema.song_credits
+	(song_id, person_id, role)
+	VALUES
+	($1, $2, $3)
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return models.SongCredit{}, fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := mr.lockSong(ctx, tx, songId); err != nil {
+		return models.SongCredit{}, err
+	}
+
+	before, err := mr.snapshotSong(ctx, tx, songId)
+	if err != nil {
+		return models.SongCredit{}, err
+	}
+
+	credit := models.SongCredit{Role: role}
+	if err := tx.QueryRowContext(ctx, queryUpsertPerson, name).Scan(&credit.ID, &credit.Name); err != nil {
+		return models.SongCredit{}, fmt.Errorf("tx.QueryRowContext: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, queryInsertCredit, songId, credit.ID, role); err != nil {
+		if isUniqueViolation(err) {
+			return models.SongCredit{}, ErrAlreadyExists
+		}
+		return models.SongCredit{}, fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	if err := mr.writeAudit(ctx, tx, songId, audit, before); err != nil {
+		return models.SongCredit{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return models.SongCredit{}, fmt.Errorf("tx.Commit: %v", err)
+	}
+
+	return credit, nil
+}
+
+// Удаляет участие человека в песне: в указанной роли или, при role = nil, во всех ролях
+func (mr *MusicRepository) DeleteCredit(ctx context.Context, songId, personId uuid.UUID, role *string, audit models.AuditEntry) error {
+	query :=
+		`
+	DELETE FROM music_schema.song_credits
+	WHERE song_id = $1 AND person_id = $2 AND ($3::text IS NULL OR role = $3::text)
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := mr.lockSong(ctx, tx, songId); err != nil {
+		return err
+	}
+
+	before, err := mr.snapshotSong(ctx, tx, songId)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, query, songId, personId, role)
+	if err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("res.RowsAffected: %v", err)
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	if err := mr.writeAudit(ctx, tx, songId, audit, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
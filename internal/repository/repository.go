@@ -5,15 +5,14 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
-	"log"
 	"strings"
+	"time"
 
 	"github.com/cutlery47/music-storage/internal/config"
 	"github.com/cutlery47/music-storage/internal/models"
 	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
-	"github.com/lib/pq"
 	_ "github.com/lib/pq"
 
 	"github.com/golang-migrate/migrate/v4"
@@ -24,17 +23,121 @@ import (
 
 type Repository interface {
 	// Добавление информации о песне
-	Create(ctx context.Context, song models.SongWithDetailSplit) error
+	Create(ctx context.Context, song models.SongWithDetailSplit, audit models.AuditEntry) (uuid.UUID, error)
 	// Получение информации о песнях по произвольным фильтрам
-	Read(ctx context.Context, limit, offset int, filter models.Filter) ([]models.SongWithDetail, error)
+	Read(ctx context.Context, limit, offset int, filter models.Filter) (models.SongsPage, error)
+	// Получение информации о песнях по произвольным фильтрам с пагинацией по курсору (пустой курсор - первая страница)
+	ReadByCursor(ctx context.Context, limit int, cursor string, filter models.Filter) (models.SongsPage, error)
+	// Получение id песни по названию группы и песни
+	ReadId(ctx context.Context, song models.Song) (uuid.UUID, error)
+	// Получение песни по id
+	ReadById(ctx context.Context, id uuid.UUID) (models.SongWithDetail, error)
 	// Получение текста песни по куплетам
-	ReadText(ctx context.Context, limit, offset int, song models.Song) ([]string, error)
+	ReadText(ctx context.Context, limit, offset int, id uuid.UUID, filter models.TextFilter) (models.SongText, error)
+	// Получение текста песни по куплетам с пагинацией по курсору (пустой курсор - первая страница)
+	ReadTextByCursor(ctx context.Context, limit int, cursor string, id uuid.UUID, filter models.TextFilter) (models.SongText, error)
 	// Получение информации о конкретной песне
-	ReadDetail(ctx context.Context, song models.Song) (models.SongDetail, error)
+	ReadDetail(ctx context.Context, id uuid.UUID) (models.SongDetail, error)
 	// Обновление информации о песне
-	Update(ctx context.Context, song models.Song, upd models.SongWithDetailSplit) error
-	// Удаление песни
-	Delete(ctx context.Context, song models.Song) error
+	Update(ctx context.Context, id uuid.UUID, upd models.SongWithDetailSplit, audit models.AuditEntry) error
+	// Частичное обновление информации о песне
+	Patch(ctx context.Context, id uuid.UUID, patch models.SongPatchSplit, audit models.AuditEntry) error
+	// Перемещение песни в корзину
+	Delete(ctx context.Context, id uuid.UUID, audit models.AuditEntry) error
+	// Полнотекстовый поиск по текстам песен
+	Search(ctx context.Context, limit, offset int, text, lang string) (models.SearchPage, error)
+
+	// Добавление группы
+	CreateGroup(ctx context.Context, groupName string, audit models.AuditEntry) (models.Group, error)
+	// Получение списка групп
+	ReadGroups(ctx context.Context, limit, offset int) ([]models.Group, error)
+	// Получение информации о конкретной группе
+	ReadGroup(ctx context.Context, id uuid.UUID) (models.GroupWithDetail, error)
+	// Получение песен группы
+	ReadGroupSongs(ctx context.Context, id uuid.UUID, limit, offset int) ([]models.SongWithDetail, error)
+	// Переименование группы
+	UpdateGroup(ctx context.Context, id uuid.UUID, groupName string, audit models.AuditEntry) error
+	// Удаление группы без песен
+	DeleteGroup(ctx context.Context, id uuid.UUID, audit models.AuditEntry) error
+
+	// Добавление альбома
+	CreateAlbum(ctx context.Context, album models.Album, audit models.AuditEntry) (models.Album, error)
+	// Получение списка альбомов (всех или конкретной группы)
+	ReadAlbums(ctx context.Context, limit, offset int, groupId *uuid.UUID) ([]models.Album, error)
+	// Получение информации о конкретном альбоме
+	ReadAlbum(ctx context.Context, id uuid.UUID) (models.AlbumWithDetail, error)
+	// Получение треклиста альбома
+	ReadAlbumTracks(ctx context.Context, id uuid.UUID) ([]models.Track, error)
+	// Обновление информации об альбоме
+	UpdateAlbum(ctx context.Context, id uuid.UUID, upd models.Album, audit models.AuditEntry) error
+	// Удаление альбома
+	DeleteAlbum(ctx context.Context, id uuid.UUID, audit models.AuditEntry) error
+	// Добавление песни в альбом или изменение её позиции
+	SetAlbumTrack(ctx context.Context, albumId, songId uuid.UUID, pos models.TrackPosition, audit models.AuditEntry) error
+	// Удаление песни из альбома
+	DeleteAlbumTrack(ctx context.Context, albumId, songId uuid.UUID, audit models.AuditEntry) error
+
+	// Добавление автора или продюсера
+	CreatePerson(ctx context.Context, name string, audit models.AuditEntry) (models.Person, error)
+	// Получение списка авторов и продюсеров
+	ReadPeople(ctx context.Context, limit, offset int) ([]models.Person, error)
+	// Получение информации о конкретном человеке
+	ReadPerson(ctx context.Context, id uuid.UUID) (models.Person, error)
+	// Получение песен, в создании которых участвовал человек
+	ReadPersonSongs(ctx context.Context, id uuid.UUID, limit, offset int) ([]models.PersonSong, error)
+	// Получение авторов и продюсеров песни
+	ReadCredits(ctx context.Context, songId uuid.UUID) ([]models.SongCredit, error)
+	// Добавление участника песни
+	CreateCredit(ctx context.Context, songId uuid.UUID, name, role string, audit models.AuditEntry) (models.SongCredit, error)
+	// Удаление участника песни (в одной или во всех ролях)
+	DeleteCredit(ctx context.Context, songId, personId uuid.UUID, role *string, audit models.AuditEntry) error
+
+	// Добавление жанра (с родительским жанром или без)
+	CreateGenre(ctx context.Context, name string, parent *string, audit models.AuditEntry) (models.Genre, error)
+	// Получение всех жанров
+	ReadGenres(ctx context.Context) ([]models.Genre, error)
+	// Удаление жанра
+	DeleteGenre(ctx context.Context, id uuid.UUID, audit models.AuditEntry) error
+	// Получение жанров песни
+	ReadSongGenres(ctx context.Context, songId uuid.UUID) ([]string, error)
+	// Замена жанров песни
+	UpdateSongGenres(ctx context.Context, songId uuid.UUID, genres []string, audit models.AuditEntry) error
+	// Получение тегов песни
+	ReadSongTags(ctx context.Context, songId uuid.UUID) ([]string, error)
+	// Замена тегов песни
+	UpdateSongTags(ctx context.Context, songId uuid.UUID, tags []string, audit models.AuditEntry) error
+	// Получение количества песен по тегам среди песен, подходящих под фильтры
+	ReadTagCounts(ctx context.Context, limit int, filter models.Filter) ([]models.TagCount, error)
+
+	// Получение ссылок песни
+	ReadLinks(ctx context.Context, songId uuid.UUID) ([]models.SongLink, error)
+	// Добавление ссылки на песню (основная ссылка заменяется)
+	CreateLink(ctx context.Context, songId uuid.UUID, link models.SongLink, audit models.AuditEntry) (models.SongLink, error)
+	// Удаление ссылки на песню
+	DeleteLink(ctx context.Context, songId, linkId uuid.UUID, audit models.AuditEntry) error
+	// Получение ссылок, которые давно не проверялись
+	ReadLinksToCheck(ctx context.Context, checkedBefore time.Time, limit int) ([]models.SongLink, error)
+	// Сохранение результата проверки ссылки
+	UpdateLinkCheck(ctx context.Context, id uuid.UUID, check models.LinkCheck) error
+	// Получение нерабочих ссылок
+	ReadBrokenLinks(ctx context.Context, limit, offset int) ([]models.BrokenLink, error)
+
+	// Получение ревизий песни
+	ReadRevisions(ctx context.Context, songId uuid.UUID, limit, offset int) (models.RevisionsPage, error)
+	// Получение ревизии песни с текстом
+	ReadRevision(ctx context.Context, songId uuid.UUID, num int) (models.RevisionWithDetail, error)
+	// Восстановление песни из ревизии (создает новую ревизию)
+	RestoreRevision(ctx context.Context, songId uuid.UUID, num int, audit models.AuditEntry) (int, error)
+
+	// Получение песен из корзины
+	ReadTrash(ctx context.Context, limit, offset int) (models.TrashPage, error)
+	// Восстановление песни из корзины
+	RestoreSong(ctx context.Context, id uuid.UUID, audit models.AuditEntry) error
+	// Окончательное удаление песен, попавших в корзину раньше deletedBefore
+	PurgeSongs(ctx context.Context, deletedBefore time.Time) (int64, error)
+
+	// Получение записей журнала аудита
+	ReadAudit(ctx context.Context, limit, offset int, filter models.AuditFilter) (models.AuditPage, error)
 }
 
 // Repository impl
@@ -93,11 +196,11 @@ func NewMusicRepository(ctx context.Context, conf config.PostgresConfig) (*Music
 	}, nil
 }
 
-func (mr *MusicRepository) Create(ctx context.Context, song models.SongWithDetailSplit) error {
+func (mr *MusicRepository) Create(ctx context.Context, song models.SongWithDetailSplit, audit models.AuditEntry) (uuid.UUID, error) {
 	queryInsertSong :=
 		`
 	INSERT INTO music_schema.songs
-	(group_name, song_name)
+	(group_id, song_name)
 	VALUES 
 	($1, $2)
 	RETURNING id
@@ -106,109 +209,330 @@ func (mr *MusicRepository) Create(ctx context.Context, song models.SongWithDetai
 	queryInsertDetail :=
 		`
 	INSERT INTO music_schema.songs_details
-	(song_id, released_at, link)
+	(song_id, released_at, duration_sec, isrc, language, explicit, bpm, musical_key)
 	VALUES
-	($1, $2, $3)
+	($1, $2, $3, $4, $5, $6, $7, $8)
 	`
 
-	queryInsertText :=
-		`
-	INSERT INTO music_schema.songs_verses
-	(song_id, verse_id, verse)
-	VALUES
-	`
-
-	// добавляем метки о всех куплетах, что хотим добавить
-	for i := range song.Verses {
-		queryInsertText += fmt.Sprintf("($%v, $%v, $%v),\n", (i+1)*3-2, (i+1)*3-1, (i+1)*3)
-	}
-	queryInsertText = strings.TrimSuffix(queryInsertText, ",\n")
-
 	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
-		return fmt.Errorf("mr.db.BeginTx: %v", err)
+		return uuid.UUID{}, fmt.Errorf("mr.db.BeginTx: %v", err)
 	}
 	defer tx.Rollback()
 
+	groupId, err := mr.upsertGroup(ctx, tx, song.GroupName)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
 	var id uuid.UUID
 
-	res := tx.QueryRowContext(ctx, queryInsertSong, song.GroupName, song.SongName)
+	res := tx.QueryRowContext(ctx, queryInsertSong, groupId, song.SongName)
 	if err := res.Scan(&id); err != nil {
 		// проверка на уникальность
-		if pqerr, ok := err.(*pq.Error); ok && pqerr.Code == "23505" {
-			return ErrAlreadyExists
+		if isUniqueViolation(err) {
+			return uuid.UUID{}, ErrAlreadyExists
 		}
-		return fmt.Errorf("res.Scan: %v", err)
+		return uuid.UUID{}, fmt.Errorf("res.Scan: %v", err)
 	}
 
-	_, err = tx.ExecContext(ctx, queryInsertDetail, id, song.ReleaseDate, song.Link)
+	_, err = tx.ExecContext(
+		ctx,
+		queryInsertDetail,
+		id,
+		song.ReleaseDate,
+		song.Duration,
+		song.ISRC,
+		song.Language,
+		song.Explicit,
+		song.BPM,
+		song.Key,
+	)
 	if err != nil {
-		return fmt.Errorf("st.ExecContext: %v", err)
+		// песня с таким ISRC уже есть
+		if isUniqueViolation(err) {
+			return uuid.UUID{}, ErrAlreadyExists
+		}
+		return uuid.UUID{}, fmt.Errorf("st.ExecContext: %v", err)
 	}
 
-	// собираем значения для prepared statements
-	var vals []interface{}
-	for i, verse := range song.Verses {
-		vals = append(vals, id, i+1, verse)
+	if err := mr.upsertPrimaryLink(ctx, tx, id, song.Link); err != nil {
+		return uuid.UUID{}, err
 	}
 
-	_, err = tx.ExecContext(ctx, queryInsertText, vals...)
-	if err != nil {
-		return fmt.Errorf("tx.ExecContext")
+	if err := mr.insertArtists(ctx, tx, id, groupId, song.Credits); err != nil {
+		return uuid.UUID{}, err
 	}
 
-	return tx.Commit()
+	if err := mr.insertVerses(ctx, tx, id, song.Verses); err != nil {
+		return uuid.UUID{}, err
+	}
+
+	if _, err := mr.insertRevision(ctx, tx, id, nil); err != nil {
+		return uuid.UUID{}, err
+	}
+
+	if err := mr.writeAudit(ctx, tx, id, audit, nil); err != nil {
+		return uuid.UUID{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return uuid.UUID{}, fmt.Errorf("tx.Commit: %v", err)
+	}
+
+	return id, nil
 }
 
-func (mr *MusicRepository) Read(ctx context.Context, limit, offset int, filter models.Filter) ([]models.SongWithDetail, error) {
+func (mr *MusicRepository) Read(ctx context.Context, limit, offset int, filter models.Filter) (models.SongsPage, error) {
 	var appliedFilters []interface{}
 
-	query :=
+	query := fmt.Sprintf(
 		`
-	SELECT s.group_name, s.song_name, sd.released_at, sd.link
+	SELECT s.id, g.group_name, s.song_name, %v, COUNT(*) OVER ()
 	FROM
 	music_schema.songs AS s
 	JOIN
-	music_schema.songs_details AS sd
+	music_schema.groups AS g
+	ON s.group_id = g.id
+	JOIN
+	music_schema.songs_details_resolved AS sd
 	ON s.id = sd.song_id
 	WHERE
-	`
+	`, detailColumns)
 
 	query = mr.applyFilters(query, filter, limit, offset, &appliedFilters)
 
 	rows, err := mr.db.QueryContext(ctx, query, appliedFilters...)
 	if err != nil {
-		return []models.SongWithDetail{}, fmt.Errorf("stmt.QueryContext: %v", err)
+		return models.SongsPage{}, fmt.Errorf("stmt.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	page := models.SongsPage{Songs: []models.SongWithDetail{}}
+	for rows.Next() {
+		song := models.SongWithDetail{}
+		if err := rows.Scan(songDest(&song, &page.Total)...); err != nil {
+			return models.SongsPage{}, fmt.Errorf("rows.Scan: %v", err)
+		}
+		page.Songs = append(page.Songs, song)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.SongsPage{}, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	// оконная функция ничего не вернет для пустой страницы, поэтому считаем отдельно
+	if len(page.Songs) == 0 && offset > 0 {
+		page.Total, err = mr.countSongs(ctx, filter)
+		if err != nil {
+			return models.SongsPage{}, err
+		}
+	}
+
+	if err := mr.attachArtists(ctx, page.Songs); err != nil {
+		return models.SongsPage{}, err
+	}
+
+	return page, nil
+}
+
+func (mr *MusicRepository) ReadByCursor(ctx context.Context, limit int, token string, filter models.Filter) (models.SongsPage, error) {
+	total, err := mr.countSongs(ctx, filter)
+	if err != nil {
+		return models.SongsPage{}, err
 	}
 
+	var applied []interface{}
+
+	conditions, keys := mr.filterConditions(filter, &applied)
+
+	var from *cursor
+	if token != "" {
+		c, err := decodeCursor(token)
+		if err != nil {
+			return models.SongsPage{}, err
+		}
+
+		condition, err := keys.after(c, &applied)
+		if err != nil {
+			return models.SongsPage{}, err
+		}
+
+		conditions = append(conditions, condition)
+		from = &c
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE\n\t" + strings.Join(conditions, "\n\tAND\n\t")
+	}
+
+	// выбираем на одну строку больше, чтобы узнать, есть ли следующая страница
+	applied = append(applied, limit+1)
+
+	query := fmt.Sprintf(
+		`
+	SELECT s.id, g.group_name, s.song_name, %v, %v
+	FROM
+	music_schema.songs AS s
+	JOIN
+	music_schema.groups AS g
+	ON s.group_id = g.id
+	JOIN
+	music_schema.songs_details_resolved AS sd
+	ON s.id = sd.song_id
+	%v
+	%v
+	LIMIT $%v
+	`, detailColumns, keys.selectValues(), where, keys.orderBy(from != nil && from.Backward), len(applied))
+
+	rows, err := mr.db.QueryContext(ctx, query, applied...)
+	if err != nil {
+		return models.SongsPage{}, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
 	songs := []models.SongWithDetail{}
+	var songKeys [][]string
+
 	for rows.Next() {
 		song := models.SongWithDetail{}
-		rows.Scan(&song.GroupName, &song.SongName, &song.ReleaseDate, &song.Link)
+		vals := make([]string, len(keys.exprs))
+
+		dest := songDest(&song)
+		for i := range vals {
+			dest = append(dest, &vals[i])
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return models.SongsPage{}, fmt.Errorf("rows.Scan: %v", err)
+		}
+
 		songs = append(songs, song)
+		songKeys = append(songKeys, vals)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.SongsPage{}, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	page := models.SongsPage{Total: total}
+	page.Songs, page.NextCursor, page.PrevCursor = paginate(keys, songs, songKeys, limit, from)
+
+	if err := mr.attachArtists(ctx, page.Songs); err != nil {
+		return models.SongsPage{}, err
+	}
+
+	return page, nil
+}
+
+// Количество песен, подходящих под фильтры
+func (mr *MusicRepository) countSongs(ctx context.Context, filter models.Filter) (int, error) {
+	var applied []interface{}
+
+	conditions, _ := mr.filterConditions(filter, &applied)
+
+	where := ""
+	if len(conditions) > 0 {
+		where = "WHERE\n\t" + strings.Join(conditions, "\n\tAND\n\t")
+	}
+
+	query := fmt.Sprintf(
+		`
+	SELECT COUNT(*)
+	FROM
+	music_schema.songs AS s
+	JOIN
+	music_schema.groups AS g
+	ON s.group_id = g.id
+	JOIN
+	music_schema.songs_details_resolved AS sd
+	ON s.id = sd.song_id
+	%v
+	`, where)
+
+	var total int
+	if err := mr.db.QueryRowContext(ctx, query, applied...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("row.Scan: %v", err)
 	}
 
-	return songs, nil
+	return total, nil
 }
 
-func (mr *MusicRepository) ReadDetail(ctx context.Context, song models.Song) (models.SongDetail, error) {
+func (mr *MusicRepository) ReadId(ctx context.Context, song models.Song) (uuid.UUID, error) {
 	query :=
 		`
-	SELECT sd.released_at, sd.link
-	FROM 
+	SELECT s.id
+	FROM
 	music_schema.songs AS s
 	JOIN
-	music_schema.songs_details AS sd
+	music_schema.groups AS g
 	ON
-	s.id = sd.song_id
+	s.group_id = g.id
 	WHERE
-	s.group_name = $1 AND s.song_name = $2
+	g.group_name = $1 AND s.song_name = $2 AND s.deleted_at IS NULL
 	`
 
-	row := mr.db.QueryRowContext(ctx, query, song.GroupName, song.SongName)
+	var id uuid.UUID
+	if err := mr.db.QueryRowContext(ctx, query, song.GroupName, song.SongName).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.UUID{}, ErrNotFound
+		}
+		return uuid.UUID{}, fmt.Errorf("row.Scan: %v", err)
+	}
+
+	return id, nil
+}
+
+func (mr *MusicRepository) ReadById(ctx context.Context, id uuid.UUID) (models.SongWithDetail, error) {
+	query := fmt.Sprintf(
+		`
+	SELECT s.id, g.group_name, s.song_name, %v
+	FROM
+	music_schema.songs AS s
+	JOIN
+	music_schema.groups AS g
+	ON
+	s.group_id = g.id
+	JOIN
+	music_schema.songs_details_resolved AS sd
+	ON
+	s.id = sd.song_id
+	WHERE
+	s.id = $1 AND s.deleted_at IS NULL
+	`, detailColumns)
+
+	song := models.SongWithDetail{}
+	if err := mr.db.QueryRowContext(ctx, query, id).Scan(songDest(&song)...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.SongWithDetail{}, ErrNotFound
+		}
+		return models.SongWithDetail{}, fmt.Errorf("row.Scan: %v", err)
+	}
+
+	artists, err := mr.readArtists(ctx, []uuid.UUID{id})
+	if err != nil {
+		return models.SongWithDetail{}, err
+	}
+	song.Artists = artists[id]
+
+	return song, nil
+}
+
+func (mr *MusicRepository) ReadDetail(ctx context.Context, id uuid.UUID) (models.SongDetail, error) {
+	query := fmt.Sprintf(
+		`
+	SELECT %v
+	FROM 
+	music_schema.songs_details_resolved AS sd
+	WHERE
+	sd.song_id = $1 AND %v
+	`, detailColumns, liveSong)
+
+	row := mr.db.QueryRowContext(ctx, query, id)
 
 	detail := models.SongDetail{}
-	if err := row.Scan(&detail.ReleaseDate, &detail.Link); err != nil {
+	if err := row.Scan(detailDest(&detail)...); err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return models.SongDetail{}, ErrNotFound
 		}
@@ -218,52 +542,206 @@ func (mr *MusicRepository) ReadDetail(ctx context.Context, song models.Song) (mo
 	return detail, nil
 }
 
-func (mr *MusicRepository) ReadText(ctx context.Context, limit, offset int, song models.Song) ([]string, error) {
-	query :=
+// условие "песня $1 не в корзине" для запросов к данным одной песни
+const liveSong = "EXISTS (SELECT 1 FROM music_schema.songs AS live WHERE live.id = $1 AND live.deleted_at IS NULL)"
+
+// столбцы songs_details_resolved (sd), которые читаются в models.SongDetail; порядок совпадает с detailDest
+const detailColumns = "sd.released_at, sd.link, sd.duration_sec, sd.isrc, sd.language, sd.explicit, sd.bpm, sd.musical_key"
+
+func detailDest(detail *models.SongDetail) []interface{} {
+	return []interface{}{
+		&detail.ReleaseDate,
+		&detail.Link,
+		&detail.Duration,
+		&detail.ISRC,
+		&detail.Language,
+		&detail.Explicit,
+		&detail.BPM,
+		&detail.Key,
+	}
+}
+
+// Получатели для столбцов "s.id, g.group_name, s.song_name, detailColumns" и дополнительных столбцов после них
+func songDest(song *models.SongWithDetail, extra ...interface{}) []interface{} {
+	dest := []interface{}{&song.ID, &song.GroupName, &song.SongName}
+	dest = append(dest, detailDest(&song.SongDetail)...)
+	return append(dest, extra...)
+}
+
+func (mr *MusicRepository) ReadText(ctx context.Context, limit, offset int, id uuid.UUID, filter models.TextFilter) (models.SongText, error) {
+	vals := []interface{}{id}
+	conditions := mr.applyTextFilters(filter, &vals)
+
+	vals = append(vals, limit, offset)
+	query := fmt.Sprintf(
 		`
-	SELECT sv.verse
+	SELECT sv.verse_num, sv.section, sv.verse, sv.repeat_of, COUNT(*) OVER ()
 	FROM 
-	music_schema.songs AS s
-	JOIN 
 	music_schema.songs_verses AS sv
-	ON 
-	s.id = sv.song_id
 	WHERE 
-	s.group_name = $1 AND s.song_name = $2
-	ORDER BY sv.verse_id
-	LIMIT $3
-	OFFSET $4 
-	`
+	sv.song_id = $1 AND %v %v
+	ORDER BY sv.verse_num
+	LIMIT $%v
+	OFFSET $%v
+	`, liveSong, conditions, len(vals)-1, len(vals))
+
+	rows, err := mr.db.QueryContext(ctx, query, vals...)
+	if err != nil {
+		return models.SongText{}, fmt.Errorf("stmt.ExecContext: %v", err)
+	}
+	defer rows.Close()
+
+	text := models.SongText{Verses: []models.Verse{}}
+	for rows.Next() {
+		verse := models.Verse{}
+		if err := rows.Scan(&verse.Num, &verse.Section, &verse.Text, &verse.RepeatOf, &text.Total); err != nil {
+			return models.SongText{}, fmt.Errorf("rows.Scan: %v", err)
+		}
+		text.Verses = append(text.Verses, verse)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.SongText{}, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	// оконная функция ничего не вернет для пустой страницы, поэтому считаем отдельно
+	if len(text.Verses) == 0 {
+		text.Total, err = mr.countVerses(ctx, id, filter)
+		if err != nil {
+			return models.SongText{}, err
+		}
+	}
+
+	return text, nil
+}
 
-	rows, err := mr.db.QueryContext(ctx, query, song.GroupName, song.SongName, limit, offset)
+func (mr *MusicRepository) ReadTextByCursor(ctx context.Context, limit int, token string, id uuid.UUID, filter models.TextFilter) (models.SongText, error) {
+	total, err := mr.countVerses(ctx, id, filter)
 	if err != nil {
-		return nil, fmt.Errorf("stmt.ExecContext: %v", err)
+		return models.SongText{}, err
+	}
+
+	vals := []interface{}{id}
+	conditions := mr.applyTextFilters(filter, &vals)
+
+	keys := keyset{}
+	keys.add("sv.verse_num", false)
+
+	var from *cursor
+	if token != "" {
+		c, err := decodeCursor(token)
+		if err != nil {
+			return models.SongText{}, err
+		}
+
+		condition, err := keys.after(c, &vals)
+		if err != nil {
+			return models.SongText{}, err
+		}
+
+		conditions += "AND " + condition + " "
+		from = &c
 	}
 
-	verses := []string{}
+	// выбираем на одну строку больше, чтобы узнать, есть ли следующая страница
+	vals = append(vals, limit+1)
+
+	query := fmt.Sprintf(
+		`
+	SELECT sv.verse_num, sv.section, sv.verse, sv.repeat_of, %v
+	FROM
+	music_schema.songs_verses AS sv
+	WHERE
+	sv.song_id = $1 AND %v %v
+	%v
+	LIMIT $%v
+	`, keys.selectValues(), liveSong, conditions, keys.orderBy(from != nil && from.Backward), len(vals))
+
+	rows, err := mr.db.QueryContext(ctx, query, vals...)
+	if err != nil {
+		return models.SongText{}, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	verses := []models.Verse{}
+	var verseKeys [][]string
+
 	for rows.Next() {
-		verse := ""
-		rows.Scan(&verse)
+		verse := models.Verse{}
+		key := make([]string, 1)
+		if err := rows.Scan(&verse.Num, &verse.Section, &verse.Text, &verse.RepeatOf, &key[0]); err != nil {
+			return models.SongText{}, fmt.Errorf("rows.Scan: %v", err)
+		}
 		verses = append(verses, verse)
+		verseKeys = append(verseKeys, key)
 	}
 
-	if len(verses) == 0 {
-		return nil, ErrNotFound
+	if err := rows.Err(); err != nil {
+		return models.SongText{}, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	text := models.SongText{Total: total}
+	text.Verses, text.NextCursor, text.PrevCursor = paginate(keys, verses, verseKeys, limit, from)
+
+	return text, nil
+}
+
+// Количество куплетов песни, подходящих под фильтры. Возвращает ErrNotFound, если песни нет
+func (mr *MusicRepository) countVerses(ctx context.Context, id uuid.UUID, filter models.TextFilter) (int, error) {
+	vals := []interface{}{id}
+	conditions := mr.applyTextFilters(filter, &vals)
+
+	query := fmt.Sprintf(
+		`
+	SELECT COUNT(sv.id)
+	FROM
+	music_schema.songs AS s
+	LEFT JOIN
+	music_schema.songs_verses AS sv
+	ON
+	s.id = sv.song_id %v
+	WHERE
+	s.id = $1 AND s.deleted_at IS NULL
+	GROUP BY s.id
+	`, conditions)
+
+	var total int
+	if err := mr.db.QueryRowContext(ctx, query, vals...).Scan(&total); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNotFound
+		}
+		return 0, fmt.Errorf("row.Scan: %v", err)
+	}
+
+	return total, nil
+}
+
+// Условия фильтрации куплетов (таблица songs_verses с алиасом sv), начинающиеся с AND.
+// Значения фильтров добавляются в vals
+func (mr *MusicRepository) applyTextFilters(filter models.TextFilter, vals *[]interface{}) string {
+	var conditions string
+
+	if filter.Section != nil {
+		*vals = append(*vals, *filter.Section)
+		conditions += fmt.Sprintf("AND sv.section = $%v ", len(*vals))
+	}
+
+	if filter.Collapse {
+		conditions += "AND sv.repeat_of IS NULL "
 	}
 
-	return verses, nil
+	return conditions
 }
 
-func (mr *MusicRepository) Update(ctx context.Context, song models.Song, upd models.SongWithDetailSplit) error {
+func (mr *MusicRepository) Update(ctx context.Context, id uuid.UUID, upd models.SongWithDetailSplit, audit models.AuditEntry) error {
 	queryUpdateSong :=
 		`
 	UPDATE music_schema.songs
 	SET 
-	group_name = $1,
+	group_id = $1,
 	song_name = $2
 	WHERE 
-	group_name = $3 AND song_name = $4
-	RETURNING id
+	id = $3 AND deleted_at IS NULL
 	`
 
 	queryUpdateDetail :=
@@ -271,9 +749,14 @@ func (mr *MusicRepository) Update(ctx context.Context, song models.Song, upd mod
 	UPDATE music_schema.songs_details
 	SET 
 	released_at = $1,
-	link = $2
+	duration_sec = $2,
+	isrc = $3,
+	language = $4,
+	explicit = $5,
+	bpm = $6,
+	musical_key = $7
 	WHERE 
-	song_id = $3
+	song_id = $8
 	`
 
 	queryDeleteOldVerses :=
@@ -282,77 +765,323 @@ func (mr *MusicRepository) Update(ctx context.Context, song models.Song, upd mod
 	WHERE sv.song_id = $1
 	`
 
-	queryAddNewVerses :=
-		`
-	INSERT INTO music_schema.songs_verses AS sv
-	(song_id, verse_id, verse)
-	VALUES
-	`
-
-	for i := range upd.Verses {
-		queryAddNewVerses += fmt.Sprintf("($%v, $%v, $%v),\n", (i+1)*3-2, (i+1)*3-1, (i+1)*3)
-	}
-	queryAddNewVerses = strings.TrimSuffix(queryAddNewVerses, ",\n")
-
 	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
 		return fmt.Errorf("mr.db.BeginTx: %v", err)
 	}
 	defer tx.Rollback()
 
-	var id uuid.UUID
+	if err := mr.lockSong(ctx, tx, id); err != nil {
+		return err
+	}
 
-	res := tx.QueryRowContext(ctx, queryUpdateSong, upd.GroupName, upd.SongName, song.GroupName, song.SongName)
-	if err := res.Scan(&id); err != nil {
+	before, err := mr.snapshotSong(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	groupId, err := mr.upsertGroup(ctx, tx, upd.GroupName)
+	if err != nil {
+		return err
+	}
+
+	res, err := tx.ExecContext(ctx, queryUpdateSong, groupId, upd.SongName, id)
+	if err != nil {
 		// проверка на уникальность
-		if errors.Is(err, sql.ErrNoRows) {
-			return ErrNotFound
+		if isUniqueViolation(err) {
+			return ErrAlreadyExists
 		}
-		return fmt.Errorf("tx.QueryRowContext: %v", err)
+		return fmt.Errorf("tx.ExecContext: %v", err)
 	}
 
-	if _, err := tx.ExecContext(ctx, queryUpdateDetail, upd.ReleaseDate, upd.Link, id); err != nil {
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("res.RowsAffected: %v", err)
+	}
+
+	if affected == 0 {
+		return ErrNotFound
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		queryUpdateDetail,
+		upd.ReleaseDate,
+		upd.Duration,
+		upd.ISRC,
+		upd.Language,
+		upd.Explicit,
+		upd.BPM,
+		upd.Key,
+		id,
+	); err != nil {
+		// песня с таким ISRC уже есть
+		if isUniqueViolation(err) {
+			return ErrAlreadyExists
+		}
 		return fmt.Errorf("tx.QueryRowContext: %v", err)
 	}
 
+	if err := mr.upsertPrimaryLink(ctx, tx, id, upd.Link); err != nil {
+		return err
+	}
+
 	if _, err := tx.ExecContext(ctx, queryDeleteOldVerses, id); err != nil {
 		return fmt.Errorf("tx.QueryRowContext %v", err)
 	}
 
-	// опять же, собираем значения для prepared statements
-	var vals []interface{}
-	for i, verse := range upd.Verses {
-		vals = append(vals, id, i+1, verse)
+	if err := mr.insertVerses(ctx, tx, id, upd.Verses); err != nil {
+		return err
 	}
 
-	if _, err := tx.ExecContext(ctx, queryAddNewVerses, vals...); err != nil {
-		return fmt.Errorf("tx.QueryRowContext %v", err)
+	if err := mr.deleteArtists(ctx, tx, id, models.ArtistRoles...); err != nil {
+		return err
+	}
+
+	if err := mr.insertArtists(ctx, tx, id, groupId, upd.Credits); err != nil {
+		return err
+	}
+
+	if _, err := mr.insertRevision(ctx, tx, id, nil); err != nil {
+		return err
+	}
+
+	if err := mr.writeAudit(ctx, tx, id, audit, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Обновляет только переданные поля. Таблицы, поля которых не были переданы, не затрагиваются
+func (mr *MusicRepository) Patch(ctx context.Context, id uuid.UUID, patch models.SongPatchSplit, audit models.AuditEntry) error {
+	queryDeleteOldVerses :=
+		`
+	DELETE FROM music_schema.songs_verses AS sv
+	WHERE sv.song_id = $1
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := mr.lockSong(ctx, tx, id); err != nil {
+		return err
+	}
+
+	before, err := mr.snapshotSong(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	// обновляем песню
+	var songSet []string
+	var songVals []interface{}
+
+	if patch.GroupName != nil {
+		groupId, err := mr.upsertGroup(ctx, tx, *patch.GroupName)
+		if err != nil {
+			return err
+		}
+		songVals = append(songVals, groupId)
+		songSet = append(songSet, fmt.Sprintf("group_id = $%v", len(songVals)))
+
+		// основной и приглашенные исполнители определяются названием группы, авторы ремиксов сохраняются
+		if err := mr.deleteArtists(ctx, tx, id, models.RolePrimary, models.RoleFeatured); err != nil {
+			return err
+		}
+
+		if err := mr.insertArtists(ctx, tx, id, groupId, patch.Featured); err != nil {
+			return err
+		}
+	}
+
+	if patch.SongName != nil {
+		songVals = append(songVals, *patch.SongName)
+		songSet = append(songSet, fmt.Sprintf("song_name = $%v", len(songVals)))
+	}
+
+	if len(songSet) > 0 {
+		songVals = append(songVals, id)
+		query := fmt.Sprintf("UPDATE music_schema.songs SET %v WHERE id = $%v", strings.Join(songSet, ", "), len(songVals))
+
+		if _, err := tx.ExecContext(ctx, query, songVals...); err != nil {
+			// проверка на уникальность
+			if isUniqueViolation(err) {
+				return ErrAlreadyExists
+			}
+			return fmt.Errorf("tx.ExecContext: %v", err)
+		}
+	}
+
+	// обновляем детали
+	var detailSet []string
+	var detailVals []interface{}
+
+	if patch.ReleaseDate != nil {
+		detailVals = append(detailVals, *patch.ReleaseDate)
+		detailSet = append(detailSet, fmt.Sprintf("released_at = $%v", len(detailVals)))
+	}
+
+	// ссылка хранится отдельно, как основная ссылка песни
+	if patch.Link != nil {
+		if err := mr.upsertPrimaryLink(ctx, tx, id, *patch.Link); err != nil {
+			return err
+		}
+	}
+
+	meta := patch.SongMetaPatch
+	for _, field := range []struct {
+		column string
+		set    bool
+		val    interface{}
+	}{
+		{"duration_sec", meta.Duration != nil, meta.Duration},
+		{"isrc", meta.ISRC != nil, meta.ISRC},
+		{"language", meta.Language != nil, meta.Language},
+		{"explicit", meta.Explicit != nil, meta.Explicit},
+		{"bpm", meta.BPM != nil, meta.BPM},
+		{"musical_key", meta.Key != nil, meta.Key},
+	} {
+		if field.set {
+			detailVals = append(detailVals, field.val)
+			detailSet = append(detailSet, fmt.Sprintf("%v = $%v", field.column, len(detailVals)))
+		}
+	}
+
+	if len(detailSet) > 0 {
+		detailVals = append(detailVals, id)
+		query := fmt.Sprintf("UPDATE music_schema.songs_details SET %v WHERE song_id = $%v", strings.Join(detailSet, ", "), len(detailVals))
+
+		if _, err := tx.ExecContext(ctx, query, detailVals...); err != nil {
+			// песня с таким ISRC уже есть
+			if isUniqueViolation(err) {
+				return ErrAlreadyExists
+			}
+			return fmt.Errorf("tx.ExecContext: %v", err)
+		}
+	}
+
+	// куплеты перезаписываются только при передаче нового текста
+	if patch.Verses != nil {
+		if _, err := tx.ExecContext(ctx, queryDeleteOldVerses, id); err != nil {
+			return fmt.Errorf("tx.ExecContext: %v", err)
+		}
+
+		if err := mr.insertVerses(ctx, tx, id, patch.Verses); err != nil {
+			return err
+		}
+	}
+
+	if _, err := mr.insertRevision(ctx, tx, id, nil); err != nil {
+		return err
+	}
+
+	if err := mr.writeAudit(ctx, tx, id, audit, before); err != nil {
+		return err
 	}
 
 	return tx.Commit()
 }
 
-func (mr *MusicRepository) Delete(ctx context.Context, song models.Song) error {
+// Перемещает песню в корзину. Окончательно песня удаляется PurgeSongs
+func (mr *MusicRepository) Delete(ctx context.Context, id uuid.UUID, audit models.AuditEntry) error {
 	query :=
 		`
-	DELETE FROM music_schema.songs AS s
-	WHERE s.group_name = $1 AND s.song_name = $2;
+	UPDATE music_schema.songs AS s
+	SET deleted_at = now()
+	WHERE s.id = $1
+	`
+
+	// ISRC песни в корзине не должен мешать добавить ту же запись заново
+	queryParkISRC :=
+		`
+	UPDATE music_schema.songs_details AS sd
+	SET deleted_isrc = sd.isrc, isrc = NULL
+	WHERE sd.song_id = $1 AND sd.isrc IS NOT NULL
 	`
 
-	res, err := mr.db.ExecContext(ctx, query, song.GroupName, song.SongName)
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
 	if err != nil {
-		return fmt.Errorf("stmt.ExecContext: %v", err)
+		return fmt.Errorf("mr.db.BeginTx: %v", err)
 	}
+	defer tx.Rollback()
 
-	affected, err := res.RowsAffected()
+	if err := mr.lockSong(ctx, tx, id); err != nil {
+		return err
+	}
+
+	before, err := mr.snapshotSong(ctx, tx, id)
 	if err != nil {
-		return fmt.Errorf("res.RowsAffected: %v", err)
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
 	}
 
-	log.Println("delete rows affected:", affected)
+	if _, err := tx.ExecContext(ctx, queryParkISRC, id); err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
+	}
 
-	if affected == 0 {
-		return ErrNotFound
+	if err := mr.writeAudit(ctx, tx, id, audit, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Возвращает id группы с указанным названием, создавая группу при необходимости
+func (mr *MusicRepository) upsertGroup(ctx context.Context, tx *sql.Tx, groupName string) (uuid.UUID, error) {
+	// DO UPDATE вместо DO NOTHING, чтобы RETURNING вернул id уже существующей группы
+	query :=
+		`
+	INSERT INTO music_schema.groups
+	(group_name)
+	VALUES
+	($1)
+	ON CONFLICT (group_name) DO UPDATE
+	SET group_name = EXCLUDED.group_name
+	RETURNING id
+	`
+
+	var id uuid.UUID
+	if err := tx.QueryRowContext(ctx, query, groupName).Scan(&id); err != nil {
+		return uuid.UUID{}, fmt.Errorf("tx.QueryRowContext: %v", err)
+	}
+
+	return id, nil
+}
+
+// Добавляет куплеты песни одним запросом
+func (mr *MusicRepository) insertVerses(ctx context.Context, tx *sql.Tx, songId uuid.UUID, verses []models.Verse) error {
+	if len(verses) == 0 {
+		return nil
+	}
+
+	query :=
+		`
+	INSERT INTO music_schema.songs_verses
+	(song_id, verse_num, section, verse, repeat_of)
+	VALUES
+	`
+
+	// добавляем метки о всех куплетах, что хотим добавить
+	for i := range verses {
+		query += fmt.Sprintf("($%v, $%v, $%v, $%v, $%v),\n", i*5+1, i*5+2, i*5+3, i*5+4, i*5+5)
+	}
+	query = strings.TrimSuffix(query, ",\n")
+
+	// собираем значения для prepared statements
+	var vals []interface{}
+	for _, verse := range verses {
+		vals = append(vals, songId, verse.Num, verse.Section, verse.Text, verse.RepeatOf)
+	}
+
+	if _, err := tx.ExecContext(ctx, query, vals...); err != nil {
+		return fmt.Errorf("tx.ExecContext: %v", err)
 	}
 
 	return nil
@@ -360,49 +1089,148 @@ func (mr *MusicRepository) Delete(ctx context.Context, song models.Song) error {
 
 // Принимаем структуру, содержащую всевозможные фильтры для поиска песни, а также лимит и оффсет для пагинации.
 // Слайс applied хранит значения фильтров, эти значения затем передаются в качестве аргументов prepared statement.
+// Ожидается, что запрос заканчивается на WHERE, и таблицы имеют алиасы s, g и sd.
 func (mr *MusicRepository) applyFilters(query string, filter models.Filter, limit, offset int, applied *[]any) string {
-	filterCount := 0
+	conditions, keys := mr.filterConditions(filter, applied)
+
+	if len(conditions) == 0 {
+		query = strings.TrimSuffix(query, "WHERE\n\t")
+	} else {
+		query += strings.Join(conditions, "\nAND\n") + "\n"
+	}
+
+	query += keys.orderBy(false) + "\n"
+
+	*applied = append(*applied, limit, offset)
+	query += fmt.Sprintf("LIMIT $%v OFFSET $%v;", len(*applied)-1, len(*applied))
+
+	return query
+}
+
+// Условия WHERE для фильтров и ключи сортировки
+func (mr *MusicRepository) filterConditions(filter models.Filter, applied *[]any) ([]string, keyset) {
+	// песни из корзины не выдаются
+	conditions := []string{"s.deleted_at IS NULL"}
+	// выражения сходства для сортировки в режиме fuzzy
+	var similarities []string
 
 	if filter.Group != nil {
-		filterCount++
-		query += fmt.Sprintf("group_name = $%v\n", filterCount)
-		*applied = append(*applied, *filter.Group)
+		*applied = append(*applied, mr.matchValue(*filter.Group, filter.Match))
+		conditions = append(conditions, mr.matchCondition("g.group_name", len(*applied), filter.Match))
+		similarities = append(similarities, fmt.Sprintf("word_similarity($%v, g.group_name)", len(*applied)))
 	}
 
 	if filter.Song != nil {
-		if filterCount > 0 {
-			query += "AND\n"
-		}
-		filterCount++
-		query += fmt.Sprintf("song_name = $%v\n", filterCount)
-		*applied = append(*applied, *filter.Song)
+		*applied = append(*applied, mr.matchValue(*filter.Song, filter.Match))
+		conditions = append(conditions, mr.matchCondition("s.song_name", len(*applied), filter.Match))
+		similarities = append(similarities, fmt.Sprintf("word_similarity($%v, s.song_name)", len(*applied)))
+	}
+
+	if filter.Artist != nil {
+		*applied = append(*applied, mr.matchValue(*filter.Artist, filter.Match))
+		conditions = append(conditions, fmt.Sprintf(
+			`EXISTS (
+		SELECT 1
+		FROM music_schema.song_artists AS sa
+		JOIN music_schema.groups AS ag
+		ON sa.group_id = ag.id
+		WHERE sa.song_id = s.id AND %v
+	)`, mr.matchCondition("ag.group_name", len(*applied), filter.Match)))
 	}
 
+	conditions = append(conditions, setConditions(filter.Tags, filter.ExcludeTags, filter.TagMatch, tagExists, applied)...)
+	conditions = append(conditions, setConditions(filter.Genres, filter.ExcludeGenres, filter.GenreMatch, genreExists, applied)...)
+
 	if filter.ReleasedAfter != nil {
-		if filterCount > 0 {
-			query += "AND\n"
-		}
-		filterCount++
-		query += fmt.Sprintf("released_at >= $%v\n", filterCount)
 		*applied = append(*applied, *filter.ReleasedAfter)
+		conditions = append(conditions, fmt.Sprintf("sd.released_at >= $%v", len(*applied)))
 	}
 
 	if filter.ReleasedBefore != nil {
-		if filterCount > 0 {
-			query += "AND\n"
-		}
-		filterCount++
-		query += fmt.Sprintf("released_at <= $%v\n", filterCount)
 		*applied = append(*applied, *filter.ReleasedBefore)
+		conditions = append(conditions, fmt.Sprintf("sd.released_at <= $%v", len(*applied)))
 	}
 
-	if filterCount == 0 {
-		query = strings.TrimSuffix(query, "WHERE\n\t")
+	// песни с неизвестным значением под условия на это значение не подходят
+	for _, cond := range []struct {
+		expr string
+		set  bool
+		val  interface{}
+	}{
+		{"sd.duration_sec >= $%v", filter.MinDuration != nil, filter.MinDuration},
+		{"sd.duration_sec <= $%v", filter.MaxDuration != nil, filter.MaxDuration},
+		{"sd.bpm >= $%v", filter.MinBPM != nil, filter.MinBPM},
+		{"sd.bpm <= $%v", filter.MaxBPM != nil, filter.MaxBPM},
+		{"sd.language = $%v", filter.Language != nil, filter.Language},
+		{"sd.explicit = $%v", filter.Explicit != nil, filter.Explicit},
+		{"sd.musical_key = $%v", filter.Key != nil, filter.Key},
+		{"sd.isrc = $%v", filter.ISRC != nil, filter.ISRC},
+	} {
+		if cond.set {
+			*applied = append(*applied, cond.val)
+			conditions = append(conditions, fmt.Sprintf(cond.expr, len(*applied)))
+		}
 	}
 
-	filterCount++
-	query += fmt.Sprintf("LIMIT $%v OFFSET $%v;", filterCount, filterCount+1)
-	*applied = append(*applied, limit, offset)
+	return conditions, mr.sortKeys(filter, similarities)
+}
 
-	return query
+// колонки, по которым разрешена сортировка.
+// Дата релиза может отсутствовать: NULL заменяется на infinity, чтобы по ней работала keyset-пагинация
+var sortColumns = map[string]string{
+	models.SortGroup:       "g.group_name",
+	models.SortSong:        "s.song_name",
+	models.SortReleaseDate: "COALESCE(sd.released_at, 'infinity'::date)",
+	models.SortCreatedAt:   "s.created_at",
+}
+
+// Ключи сортировки. Явно заданная сортировка имеет приоритет, иначе в режиме fuzzy
+// песни упорядочиваются по сходству, а по умолчанию - по группе и названию.
+// Последним ключом всегда идет id, чтобы порядок был стабильным при пагинации
+func (mr *MusicRepository) sortKeys(filter models.Filter, similarities []string) keyset {
+	keys := keyset{}
+
+	for _, key := range filter.Sort {
+		if column, ok := sortColumns[key.Field]; ok {
+			keys.add(column, key.Desc)
+		}
+	}
+
+	if len(keys.exprs) == 0 {
+		if filter.Match == models.MatchFuzzy && len(similarities) > 0 {
+			keys.add(strings.Join(similarities, " + "), true)
+		}
+		keys.add("g.group_name", false)
+		keys.add("s.song_name", false)
+	}
+
+	keys.add("s.id", false)
+
+	return keys
+}
+
+// Условие сопоставления колонки с аргументом $argNum в зависимости от режима
+func (mr *MusicRepository) matchCondition(column string, argNum int, match string) string {
+	switch match {
+	case models.MatchInsensitive:
+		return fmt.Sprintf("lower(%v) = lower($%v)", column, argNum)
+	case models.MatchPrefix:
+		return fmt.Sprintf("%v ILIKE $%v", column, argNum)
+	case models.MatchFuzzy:
+		return fmt.Sprintf("$%v <%% %v", argNum, column)
+	default:
+		return fmt.Sprintf("%v = $%v", column, argNum)
+	}
+}
+
+// экранирование спецсимволов LIKE
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Значение аргумента для сопоставления в зависимости от режима
+func (mr *MusicRepository) matchValue(val, match string) string {
+	if match == models.MatchPrefix {
+		return likeEscaper.Replace(val) + "%"
+	}
+
+	return val
 }
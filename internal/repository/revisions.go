@@ -0,0 +1,339 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+)
+
+// общие методы *sql.DB и *sql.Tx, чтобы читать ревизии как отдельно, так и внутри транзакции
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// Ревизии песни от новых к старым
+func (mr *MusicRepository) ReadRevisions(ctx context.Context, songId uuid.UUID, limit, offset int) (models.RevisionsPage, error) {
+	queryCount := fmt.Sprintf(
+		`
+	SELECT COUNT(*)
+	FROM music_schema.song_revisions AS r
+	WHERE r.song_id = $1 AND %v
+	`, liveSong)
+
+	query :=
+		`
+	SELECT r.revision_num, r.group_name, r.song_name, r.author, r.created_at, r.restored_from
+	FROM
+	music_schema.song_revisions AS r
+	WHERE
+	r.song_id = $1
+	ORDER BY r.revision_num DESC
+	LIMIT $2
+	OFFSET $3
+	`
+
+	page := models.RevisionsPage{Revisions: []models.Revision{}}
+	if err := mr.db.QueryRowContext(ctx, queryCount, songId).Scan(&page.Total); err != nil {
+		return models.RevisionsPage{}, fmt.Errorf("mr.db.QueryRowContext: %v", err)
+	}
+
+	// у существующей песни не из корзины есть хотя бы одна ревизия
+	if page.Total == 0 {
+		return models.RevisionsPage{}, ErrNotFound
+	}
+
+	rows, err := mr.db.QueryContext(ctx, query, songId, limit, offset)
+	if err != nil {
+		return models.RevisionsPage{}, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		rev := models.Revision{}
+		if err := rows.Scan(revisionDest(&rev)...); err != nil {
+			return models.RevisionsPage{}, fmt.Errorf("rows.Scan: %v", err)
+		}
+		page.Revisions = append(page.Revisions, rev)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.RevisionsPage{}, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	return page, nil
+}
+
+func (mr *MusicRepository) ReadRevision(ctx context.Context, songId uuid.UUID, num int) (models.RevisionWithDetail, error) {
+	return mr.readRevision(ctx, mr.db, songId, num)
+}
+
+// Восстанавливает песню из ревизии num и записывает результат новой ревизией.
+// Исполнители, кроме основного, жанры, теги и дополнительные ссылки не изменяются
+func (mr *MusicRepository) RestoreRevision(ctx context.Context, songId uuid.UUID, num int, audit models.AuditEntry) (int, error) {
+	queryLockSong :=
+		`
+	SELECT s.group_id
+	FROM music_schema.songs AS s
+	WHERE s.id = $1 AND s.deleted_at IS NULL
+	FOR UPDATE
+	`
+
+	queryUpdateSong :=
+		`
+	UPDATE music_schema.songs
+	SET 
+	group_id = $1,
+	song_name = $2
+	WHERE 
+	id = $3
+	`
+
+	queryUpdateDetail :=
+		`
+	UPDATE music_schema.songs_details
+	SET 
+	released_at = $1,
+	duration_sec = $2,
+	isrc = $3,
+	language = $4,
+	explicit = $5,
+	bpm = $6,
+	musical_key = $7
+	WHERE 
+	song_id = $8
+	`
+
+	queryDeleteOldVerses :=
+		`
+	DELETE FROM music_schema.songs_verses AS sv
+	WHERE sv.song_id = $1
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	var oldGroupId uuid.UUID
+	if err := tx.QueryRowContext(ctx, queryLockSong, songId).Scan(&oldGroupId); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNotFound
+		}
+		return 0, fmt.Errorf("tx.QueryRowContext: %v", err)
+	}
+
+	rev, err := mr.readRevision(ctx, tx, songId, num)
+	if err != nil {
+		return 0, err
+	}
+
+	before, err := mr.snapshotSong(ctx, tx, songId)
+	if err != nil {
+		return 0, err
+	}
+
+	groupId, err := mr.upsertGroup(ctx, tx, rev.GroupName)
+	if err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, queryUpdateSong, groupId, rev.SongName, songId); err != nil {
+		// название уже занято другой песней группы
+		if isUniqueViolation(err) {
+			return 0, ErrAlreadyExists
+		}
+		return 0, fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	// основной исполнитель определяется группой
+	if groupId != oldGroupId {
+		if err := mr.deleteArtists(ctx, tx, songId, models.RolePrimary); err != nil {
+			return 0, err
+		}
+
+		if err := mr.insertArtists(ctx, tx, songId, groupId, nil); err != nil {
+			return 0, err
+		}
+	}
+
+	if _, err := tx.ExecContext(
+		ctx,
+		queryUpdateDetail,
+		rev.ReleaseDate,
+		rev.Duration,
+		rev.ISRC,
+		rev.Language,
+		rev.Explicit,
+		rev.BPM,
+		rev.Key,
+		songId,
+	); err != nil {
+		// ISRC ревизии уже занят другой песней
+		if isUniqueViolation(err) {
+			return 0, ErrAlreadyExists
+		}
+		return 0, fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	if err := mr.upsertPrimaryLink(ctx, tx, songId, rev.Link); err != nil {
+		return 0, err
+	}
+
+	if _, err := tx.ExecContext(ctx, queryDeleteOldVerses, songId); err != nil {
+		return 0, fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	if err := mr.insertVerses(ctx, tx, songId, rev.Verses); err != nil {
+		return 0, err
+	}
+
+	restored, err := mr.insertRevision(ctx, tx, songId, &num)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := mr.writeAudit(ctx, tx, songId, audit, before); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("tx.Commit: %v", err)
+	}
+
+	return restored, nil
+}
+
+func (mr *MusicRepository) readRevision(ctx context.Context, q querier, songId uuid.UUID, num int) (models.RevisionWithDetail, error) {
+	query := fmt.Sprintf(
+		`
+	SELECT r.id, r.revision_num, r.group_name, r.song_name, r.author, r.created_at, r.restored_from,
+	r.released_at, r.link, r.duration_sec, r.isrc, r.language, r.explicit, r.bpm, r.musical_key
+	FROM
+	music_schema.song_revisions AS r
+	WHERE
+	r.song_id = $1 AND r.revision_num = $2 AND %v
+	`, liveSong)
+
+	queryVerses :=
+		`
+	SELECT rv.verse_num, rv.section, rv.verse, rv.repeat_of
+	FROM
+	music_schema.song_revision_verses AS rv
+	WHERE
+	rv.revision_id = $1
+	ORDER BY rv.verse_num
+	`
+
+	var revId uuid.UUID
+	rev := models.RevisionWithDetail{Verses: []models.Verse{}}
+
+	dest := append([]interface{}{&revId}, revisionDest(&rev.Revision)...)
+	dest = append(dest, detailDest(&rev.SongDetail)...)
+	if err := q.QueryRowContext(ctx, query, songId, num).Scan(dest...); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return models.RevisionWithDetail{}, ErrNotFound
+		}
+		return models.RevisionWithDetail{}, fmt.Errorf("q.QueryRowContext: %v", err)
+	}
+
+	rows, err := q.QueryContext(ctx, queryVerses, revId)
+	if err != nil {
+		return models.RevisionWithDetail{}, fmt.Errorf("q.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		verse := models.Verse{}
+		if err := rows.Scan(&verse.Num, &verse.Section, &verse.Text, &verse.RepeatOf); err != nil {
+			return models.RevisionWithDetail{}, fmt.Errorf("rows.Scan: %v", err)
+		}
+		rev.Verses = append(rev.Verses, verse)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.RevisionWithDetail{}, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	return rev, nil
+}
+
+// Записывает текущее состояние песни новой ревизией и возвращает её номер.
+// Вызывается в конце транзакции изменения, когда строка песни уже заблокирована
+func (mr *MusicRepository) insertRevision(ctx context.Context, tx *sql.Tx, songId uuid.UUID, restoredFrom *int) (int, error) {
+	query :=
+		`
+	INSERT INTO music_schema.song_revisions
+	(song_id, revision_num, group_name, song_name, released_at, link, duration_sec, isrc, language, explicit, bpm, musical_key, author, restored_from)
+	SELECT
+	s.id,
+	COALESCE((SELECT MAX(r.revision_num) FROM music_schema.song_revisions AS r WHERE r.song_id = s.id), 0) + 1,
+	g.group_name,
+	s.song_name,
+	sd.released_at,
+	COALESCE((SELECT l.url::text FROM music_schema.song_links AS l WHERE l.song_id = s.id AND l.link_type = 'primary'), ''),
+	sd.duration_sec,
+	sd.isrc,
+	sd.language,
+	sd.explicit,
+	sd.bpm,
+	sd.musical_key,
+	$2::text,
+	$3::integer
+	FROM
+	music_schema.songs AS s
+	JOIN
+	music_schema.groups AS g
+	ON g.id = s.group_id
+	JOIN
+	music_schema.songs_details AS sd
+	ON sd.song_id = s.id
+	WHERE
+	s.id = $1
+	RETURNING id, revision_num
+	`
+
+	queryVerses :=
+		`
+	INSERT INTO music_schema.song_revision_verses
+	(revision_id, verse_num, section, verse, repeat_of)
+	SELECT $1, sv.verse_num, sv.section, sv.verse, sv.repeat_of
+	FROM music_schema.songs_verses AS sv
+	WHERE sv.song_id = $2
+	`
+
+	var (
+		revId uuid.UUID
+		num   int
+	)
+
+	if err := tx.QueryRowContext(ctx, query, songId, models.Author(ctx), restoredFrom).Scan(&revId, &num); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, ErrNotFound
+		}
+		return 0, fmt.Errorf("tx.QueryRowContext: %v", err)
+	}
+
+	if _, err := tx.ExecContext(ctx, queryVerses, revId, songId); err != nil {
+		return 0, fmt.Errorf("tx.ExecContext: %v", err)
+	}
+
+	return num, nil
+}
+
+// Получатели для столбцов "revision_num, group_name, song_name, author, created_at, restored_from"
+func revisionDest(rev *models.Revision) []interface{} {
+	return []interface{}{
+		&rev.Num,
+		&rev.GroupName,
+		&rev.SongName,
+		&rev.Author,
+		&rev.CreatedAt,
+		&rev.RestoredFrom,
+	}
+}
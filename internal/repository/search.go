@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+)
+
+// Поддерживаемые конфигурации полнотекстового поиска.
+// Название конфигурации подставляется в запрос литералом, иначе postgres не использует индексы
+var searchConfigs = map[string]string{
+	"english": "english",
+	"en":      "english",
+	"russian": "russian",
+	"ru":      "russian",
+	"simple":  "simple",
+}
+
+// Полнотекстовый поиск по куплетам. Для каждой песни возвращается наиболее релевантный куплет,
+// песни упорядочены по убыванию релевантности
+func (mr *MusicRepository) Search(ctx context.Context, limit, offset int, text, lang string) (models.SearchPage, error) {
+	conf, ok := searchConfigs[strings.ToLower(lang)]
+	if !ok {
+		return models.SearchPage{}, ErrBadLanguage
+	}
+
+	from :=
+		`
+	FROM (
+		SELECT DISTINCT ON (sv.song_id) sv.song_id, sv.verse_num, sv.verse, ts_rank(to_tsvector('{conf}', sv.verse), q) AS rank
+		FROM
+		music_schema.songs_verses AS sv,
+		websearch_to_tsquery('{conf}', $1) AS q
+		WHERE
+		to_tsvector('{conf}', sv.verse) @@ q
+		ORDER BY sv.song_id, rank DESC, sv.verse_num
+	) AS best
+	JOIN
+	music_schema.songs AS s
+	ON s.id = best.song_id
+	JOIN
+	music_schema.groups AS g
+	ON s.group_id = g.id
+	JOIN
+	music_schema.songs_details_resolved AS sd
+	ON s.id = sd.song_id
+	WHERE
+	s.deleted_at IS NULL
+	`
+
+	query := strings.ReplaceAll(fmt.Sprintf(
+		`
+	SELECT s.id, g.group_name, s.song_name, %v, best.verse_num, best.rank,
+	ts_headline('{conf}', best.verse, websearch_to_tsquery('{conf}', $1), 'StartSel=<mark>, StopSel=</mark>, HighlightAll=true'),
+	COUNT(*) OVER ()
+	%v
+	ORDER BY best.rank DESC, g.group_name, s.song_name
+	LIMIT $2
+	OFFSET $3
+	`, detailColumns, from), "{conf}", conf)
+
+	rows, err := mr.db.QueryContext(ctx, query, text, limit, offset)
+	if err != nil {
+		return models.SearchPage{}, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	page := models.SearchPage{Results: []models.SearchResult{}}
+	for rows.Next() {
+		res := models.SearchResult{}
+		if err := rows.Scan(songDest(&res.SongWithDetail, &res.VerseNum, &res.Rank, &res.Highlight, &page.Total)...); err != nil {
+			return models.SearchPage{}, fmt.Errorf("rows.Scan: %v", err)
+		}
+		page.Results = append(page.Results, res)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.SearchPage{}, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	// оконная функция ничего не вернет для пустой страницы, поэтому считаем отдельно
+	if len(page.Results) == 0 && offset > 0 {
+		queryCount := strings.ReplaceAll("SELECT COUNT(*)"+from, "{conf}", conf)
+		if err := mr.db.QueryRowContext(ctx, queryCount, text).Scan(&page.Total); err != nil {
+			return models.SearchPage{}, fmt.Errorf("mr.db.QueryRowContext: %v", err)
+		}
+	}
+
+	ids := make([]uuid.UUID, len(page.Results))
+	for i, res := range page.Results {
+		ids[i] = res.ID
+	}
+
+	artists, err := mr.readArtists(ctx, ids)
+	if err != nil {
+		return models.SearchPage{}, err
+	}
+
+	for i := range page.Results {
+		page.Results[i].Artists = artists[page.Results[i].ID]
+	}
+
+	return page, nil
+}
@@ -0,0 +1,189 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+)
+
+// Песни в корзине, начиная с недавно удаленных
+func (mr *MusicRepository) ReadTrash(ctx context.Context, limit, offset int) (models.TrashPage, error) {
+	queryCount :=
+		`
+	SELECT COUNT(*)
+	FROM music_schema.songs AS s
+	WHERE s.deleted_at IS NOT NULL
+	`
+
+	query :=
+		`
+	SELECT s.id, g.group_name, s.song_name, s.deleted_at
+	FROM
+	music_schema.songs AS s
+	JOIN
+	music_schema.groups AS g
+	ON s.group_id = g.id
+	WHERE
+	s.deleted_at IS NOT NULL
+	ORDER BY s.deleted_at DESC, s.id
+	LIMIT $1
+	OFFSET $2
+	`
+
+	page := models.TrashPage{Songs: []models.DeletedSong{}}
+	if err := mr.db.QueryRowContext(ctx, queryCount).Scan(&page.Total); err != nil {
+		return models.TrashPage{}, fmt.Errorf("mr.db.QueryRowContext: %v", err)
+	}
+
+	rows, err := mr.db.QueryContext(ctx, query, limit, offset)
+	if err != nil {
+		return models.TrashPage{}, fmt.Errorf("mr.db.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		song := models.DeletedSong{}
+		if err := rows.Scan(&song.ID, &song.GroupName, &song.SongName, &song.DeletedAt); err != nil {
+			return models.TrashPage{}, fmt.Errorf("rows.Scan: %v", err)
+		}
+		page.Songs = append(page.Songs, song)
+	}
+
+	if err := rows.Err(); err != nil {
+		return models.TrashPage{}, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	return page, nil
+}
+
+// Возвращает песню из корзины. ErrAlreadyExists - у группы уже есть песня с таким названием
+// или ISRC песни занят другой песней
+func (mr *MusicRepository) RestoreSong(ctx context.Context, id uuid.UUID, audit models.AuditEntry) error {
+	queryLockDeleted :=
+		`
+	SELECT s.id
+	FROM music_schema.songs AS s
+	WHERE s.id = $1 AND s.deleted_at IS NOT NULL
+	FOR UPDATE
+	`
+
+	query :=
+		`
+	UPDATE music_schema.songs AS s
+	SET deleted_at = NULL
+	WHERE s.id = $1
+	`
+
+	queryRestoreISRC :=
+		`
+	UPDATE music_schema.songs_details AS sd
+	SET isrc = sd.deleted_isrc, deleted_isrc = NULL
+	WHERE sd.song_id = $1 AND sd.deleted_isrc IS NOT NULL
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	if err := tx.QueryRowContext(ctx, queryLockDeleted, id).Scan(&id); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotFound
+		}
+		return fmt.Errorf("tx.QueryRowContext: %v", err)
+	}
+
+	before, err := mr.snapshotSong(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+
+	for _, query := range []string{query, queryRestoreISRC} {
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			if isUniqueViolation(err) {
+				return ErrAlreadyExists
+			}
+			return fmt.Errorf("tx.ExecContext: %v", err)
+		}
+	}
+
+	if err := mr.writeAudit(ctx, tx, id, audit, before); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Окончательно удаляет песни, попавшие в корзину раньше deletedBefore, вместе с текстами и деталями.
+// Для каждой песни в журнал аудита записывается её последнее состояние
+func (mr *MusicRepository) PurgeSongs(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	queryLock :=
+		`
+	SELECT s.id
+	FROM music_schema.songs AS s
+	WHERE s.deleted_at < $1
+	ORDER BY s.id
+	FOR UPDATE
+	`
+
+	query :=
+		`
+	DELETE FROM music_schema.songs AS s
+	WHERE s.id = $1
+	`
+
+	tx, err := mr.db.BeginTx(ctx, &sql.TxOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("mr.db.BeginTx: %v", err)
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx, queryLock, deletedBefore)
+	if err != nil {
+		return 0, fmt.Errorf("tx.QueryContext: %v", err)
+	}
+	defer rows.Close()
+
+	ids := []uuid.UUID{}
+	for rows.Next() {
+		var id uuid.UUID
+		if err := rows.Scan(&id); err != nil {
+			return 0, fmt.Errorf("rows.Scan: %v", err)
+		}
+		ids = append(ids, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("rows.Err: %v", err)
+	}
+
+	// очистку запускает фоновая задача, автора и запроса у неё нет
+	audit := models.AuditEntry{Action: models.AuditPurge}
+
+	for _, id := range ids {
+		before, err := mr.snapshotSong(ctx, tx, id)
+		if err != nil {
+			return 0, err
+		}
+
+		if _, err := tx.ExecContext(ctx, query, id); err != nil {
+			return 0, fmt.Errorf("tx.ExecContext: %v", err)
+		}
+
+		if err := mr.writeAudit(ctx, tx, id, audit, before); err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("tx.Commit: %v", err)
+	}
+
+	return int64(len(ids)), nil
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+)
+
+func (ms *MusicService) CreateAlbum(ctx context.Context, album models.Album) (models.Album, error) {
+	return ms.repo.CreateAlbum(ctx, album, auditEntry(ctx, models.AuditCreate))
+}
+
+func (ms *MusicService) GetAlbums(ctx context.Context, limit, offset int, groupId *uuid.UUID) ([]models.Album, error) {
+	return ms.repo.ReadAlbums(ctx, limit, offset, groupId)
+}
+
+func (ms *MusicService) GetAlbum(ctx context.Context, id uuid.UUID) (models.AlbumWithDetail, error) {
+	return ms.repo.ReadAlbum(ctx, id)
+}
+
+func (ms *MusicService) GetAlbumTracks(ctx context.Context, id uuid.UUID) ([]models.Track, error) {
+	return ms.repo.ReadAlbumTracks(ctx, id)
+}
+
+func (ms *MusicService) UpdateAlbum(ctx context.Context, id uuid.UUID, upd models.Album) error {
+	return ms.repo.UpdateAlbum(ctx, id, upd, auditEntry(ctx, models.AuditUpdate))
+}
+
+func (ms *MusicService) DeleteAlbum(ctx context.Context, id uuid.UUID) error {
+	return ms.repo.DeleteAlbum(ctx, id, auditEntry(ctx, models.AuditDelete))
+}
+
+func (ms *MusicService) SetAlbumTrack(ctx context.Context, albumId, songId uuid.UUID, pos models.TrackPosition) error {
+	return ms.repo.SetAlbumTrack(ctx, albumId, songId, pos, auditEntry(ctx, models.AuditUpdate))
+}
+
+func (ms *MusicService) RemoveAlbumTrack(ctx context.Context, albumId, songId uuid.UUID) error {
+	return ms.repo.DeleteAlbumTrack(ctx, albumId, songId, auditEntry(ctx, models.AuditUpdate))
+}
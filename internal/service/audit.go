@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cutlery47/music-storage/internal/models"
+)
+
+func (ms *MusicService) GetAudit(ctx context.Context, limit, offset int, filter models.AuditFilter) (models.AuditPage, error) {
+	return ms.repo.ReadAudit(ctx, limit, offset, filter)
+}
+
+// Запись журнала аудита для действия: автор и сведения о запросе берутся из контекста.
+// Id сущности и её состояние до и после изменения дописывает хранилище в транзакции изменения
+func auditEntry(ctx context.Context, action string) models.AuditEntry {
+	req := models.Request(ctx)
+
+	return models.AuditEntry{
+		Actor:     models.Author(ctx),
+		Action:    action,
+		RequestID: optional(req.ID),
+		IP:        optional(req.IP),
+	}
+}
+
+// nil вместо пустой строки
+func optional(val string) *string {
+	if val == "" {
+		return nil
+	}
+	return &val
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+)
+
+func (ms *MusicService) CreateGenre(ctx context.Context, name string, parent *string) (models.Genre, error) {
+	return ms.repo.CreateGenre(ctx, name, parent, auditEntry(ctx, models.AuditCreate))
+}
+
+func (ms *MusicService) GetGenres(ctx context.Context) ([]models.Genre, error) {
+	return ms.repo.ReadGenres(ctx)
+}
+
+func (ms *MusicService) DeleteGenre(ctx context.Context, id uuid.UUID) error {
+	return ms.repo.DeleteGenre(ctx, id, auditEntry(ctx, models.AuditDelete))
+}
+
+func (ms *MusicService) GetSongGenres(ctx context.Context, songId uuid.UUID) ([]string, error) {
+	return ms.repo.ReadSongGenres(ctx, songId)
+}
+
+func (ms *MusicService) SetSongGenres(ctx context.Context, songId uuid.UUID, genres []string) error {
+	return ms.repo.UpdateSongGenres(ctx, songId, genres, auditEntry(ctx, models.AuditUpdate))
+}
+
+func (ms *MusicService) GetSongTags(ctx context.Context, songId uuid.UUID) ([]string, error) {
+	return ms.repo.ReadSongTags(ctx, songId)
+}
+
+func (ms *MusicService) SetSongTags(ctx context.Context, songId uuid.UUID, tags []string) error {
+	return ms.repo.UpdateSongTags(ctx, songId, tags, auditEntry(ctx, models.AuditUpdate))
+}
+
+func (ms *MusicService) GetTagCounts(ctx context.Context, limit int, filter models.Filter) ([]models.TagCount, error) {
+	return ms.repo.ReadTagCounts(ctx, limit, filter)
+}
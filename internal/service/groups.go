@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+)
+
+func (ms *MusicService) CreateGroup(ctx context.Context, groupName string) (models.Group, error) {
+	return ms.repo.CreateGroup(ctx, groupName, auditEntry(ctx, models.AuditCreate))
+}
+
+func (ms *MusicService) GetGroups(ctx context.Context, limit, offset int) ([]models.Group, error) {
+	return ms.repo.ReadGroups(ctx, limit, offset)
+}
+
+func (ms *MusicService) GetGroup(ctx context.Context, id uuid.UUID) (models.GroupWithDetail, error) {
+	return ms.repo.ReadGroup(ctx, id)
+}
+
+func (ms *MusicService) GetGroupSongs(ctx context.Context, id uuid.UUID, limit, offset int) ([]models.SongWithDetail, error) {
+	return ms.repo.ReadGroupSongs(ctx, id, limit, offset)
+}
+
+func (ms *MusicService) RenameGroup(ctx context.Context, id uuid.UUID, groupName string) error {
+	return ms.repo.UpdateGroup(ctx, id, groupName, auditEntry(ctx, models.AuditUpdate))
+}
+
+func (ms *MusicService) DeleteGroup(ctx context.Context, id uuid.UUID) error {
+	return ms.repo.DeleteGroup(ctx, id, auditEntry(ctx, models.AuditDelete))
+}
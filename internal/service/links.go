@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+)
+
+func (ms *MusicService) GetLinks(ctx context.Context, songId uuid.UUID) ([]models.SongLink, error) {
+	return ms.repo.ReadLinks(ctx, songId)
+}
+
+func (ms *MusicService) AddLink(ctx context.Context, songId uuid.UUID, link models.SongLink) (models.SongLink, error) {
+	return ms.repo.CreateLink(ctx, songId, link, auditEntry(ctx, models.AuditUpdate))
+}
+
+func (ms *MusicService) RemoveLink(ctx context.Context, songId, linkId uuid.UUID) error {
+	return ms.repo.DeleteLink(ctx, songId, linkId, auditEntry(ctx, models.AuditUpdate))
+}
+
+func (ms *MusicService) GetBrokenLinks(ctx context.Context, limit, offset int) ([]models.BrokenLink, error) {
+	return ms.repo.ReadBrokenLinks(ctx, limit, offset)
+}
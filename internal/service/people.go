@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+)
+
+func (ms *MusicService) CreatePerson(ctx context.Context, name string) (models.Person, error) {
+	return ms.repo.CreatePerson(ctx, name, auditEntry(ctx, models.AuditCreate))
+}
+
+func (ms *MusicService) GetPeople(ctx context.Context, limit, offset int) ([]models.Person, error) {
+	return ms.repo.ReadPeople(ctx, limit, offset)
+}
+
+func (ms *MusicService) GetPerson(ctx context.Context, id uuid.UUID) (models.Person, error) {
+	return ms.repo.ReadPerson(ctx, id)
+}
+
+func (ms *MusicService) GetPersonSongs(ctx context.Context, id uuid.UUID, limit, offset int) ([]models.PersonSong, error) {
+	return ms.repo.ReadPersonSongs(ctx, id, limit, offset)
+}
+
+func (ms *MusicService) AddCredit(ctx context.Context, songId uuid.UUID, name, role string) (models.SongCredit, error) {
+	return ms.repo.CreateCredit(ctx, songId, name, role, auditEntry(ctx, models.AuditUpdate))
+}
+
+func (ms *MusicService) RemoveCredit(ctx context.Context, songId, personId uuid.UUID, role *string) error {
+	return ms.repo.DeleteCredit(ctx, songId, personId, role, auditEntry(ctx, models.AuditUpdate))
+}
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+)
+
+func (ms *MusicService) GetRevisions(ctx context.Context, songId uuid.UUID, limit, offset int) (models.RevisionsPage, error) {
+	return ms.repo.ReadRevisions(ctx, songId, limit, offset)
+}
+
+func (ms *MusicService) GetRevision(ctx context.Context, songId uuid.UUID, num int) (models.RevisionWithDetail, error) {
+	return ms.repo.ReadRevision(ctx, songId, num)
+}
+
+func (ms *MusicService) DiffRevisions(ctx context.Context, songId uuid.UUID, from, to int) (models.RevisionDiff, error) {
+	fromRev, err := ms.repo.ReadRevision(ctx, songId, from)
+	if err != nil {
+		return models.RevisionDiff{}, err
+	}
+
+	toRev, err := ms.repo.ReadRevision(ctx, songId, to)
+	if err != nil {
+		return models.RevisionDiff{}, err
+	}
+
+	return models.Diff(fromRev, toRev), nil
+}
+
+func (ms *MusicService) RestoreRevision(ctx context.Context, songId uuid.UUID, num int) (int, error) {
+	return ms.repo.RestoreRevision(ctx, songId, num, auditEntry(ctx, models.AuditUpdate))
+}
@@ -2,76 +2,249 @@ package service
 
 import (
 	"context"
-	"fmt"
-	"strings"
 
+	"github.com/cutlery47/music-storage/internal/config"
 	"github.com/cutlery47/music-storage/internal/models"
 	"github.com/cutlery47/music-storage/internal/repository"
+	"github.com/cutlery47/music-storage/internal/songinfo"
+	"github.com/cutlery47/music-storage/pkg/httpclient"
+	"github.com/google/uuid"
 )
 
 type Service interface {
 	// Обработка текста и передача в хранилище
-	Create(ctx context.Context, song models.SongWithDetailPlain) error
+	Create(ctx context.Context, song models.SongWithDetailPlain) (uuid.UUID, error)
 	// Получение информации о песнях по произвольным фильтрам
-	GetSongs(ctx context.Context, limit, offset int, filter models.Filter) ([]models.SongWithDetail, error)
+	GetSongs(ctx context.Context, limit, offset int, filter models.Filter) (models.SongsPage, error)
+	// Получение информации о песнях по произвольным фильтрам с пагинацией по курсору
+	GetSongsByCursor(ctx context.Context, limit int, cursor string, filter models.Filter) (models.SongsPage, error)
+	// Получение id песни по названию группы и песни
+	GetId(ctx context.Context, song models.Song) (uuid.UUID, error)
+	// Получение песни по id
+	GetSong(ctx context.Context, id uuid.UUID) (models.SongWithDetail, error)
 	// Получение текста песни по куплетам
-	GetText(ctx context.Context, limit, offset int, song models.Song) (string, error)
-	// Получение информации о конкретной песне
-	GetDetail(ctx context.Context, song models.Song) (models.SongDetail, error)
+	GetText(ctx context.Context, limit, offset int, id uuid.UUID, filter models.TextFilter) (models.SongText, error)
+	// Получение текста песни по куплетам с пагинацией по курсору
+	GetTextByCursor(ctx context.Context, limit int, cursor string, id uuid.UUID, filter models.TextFilter) (models.SongText, error)
+	// Получение информации о конкретной песне вместе с авторами и продюсерами
+	GetDetail(ctx context.Context, id uuid.UUID) (models.SongDetailWithCredits, error)
 	// Обновление информации о песне
-	Update(ctx context.Context, song models.Song, upd models.SongWithDetailPlain) error
-	// Удаление песни
-	Delete(ctx context.Context, song models.Song) error
+	Update(ctx context.Context, id uuid.UUID, upd models.SongWithDetailPlain) error
+	// Частичное обновление информации о песне
+	Patch(ctx context.Context, id uuid.UUID, patch models.SongPatchPlain) error
+	// Перемещение песни в корзину
+	Delete(ctx context.Context, id uuid.UUID) error
+	// Полнотекстовый поиск по текстам песен. Пустой lang - язык по умолчанию
+	Search(ctx context.Context, limit, offset int, text, lang string) (models.SearchPage, error)
+
+	// Добавление группы
+	CreateGroup(ctx context.Context, groupName string) (models.Group, error)
+	// Получение списка групп
+	GetGroups(ctx context.Context, limit, offset int) ([]models.Group, error)
+	// Получение информации о конкретной группе
+	GetGroup(ctx context.Context, id uuid.UUID) (models.GroupWithDetail, error)
+	// Получение песен группы
+	GetGroupSongs(ctx context.Context, id uuid.UUID, limit, offset int) ([]models.SongWithDetail, error)
+	// Переименование группы (затрагивает все песни группы)
+	RenameGroup(ctx context.Context, id uuid.UUID, groupName string) error
+	// Удаление группы без песен
+	DeleteGroup(ctx context.Context, id uuid.UUID) error
+
+	// Добавление альбома
+	CreateAlbum(ctx context.Context, album models.Album) (models.Album, error)
+	// Получение списка альбомов (всех или конкретной группы)
+	GetAlbums(ctx context.Context, limit, offset int, groupId *uuid.UUID) ([]models.Album, error)
+	// Получение информации о конкретном альбоме
+	GetAlbum(ctx context.Context, id uuid.UUID) (models.AlbumWithDetail, error)
+	// Получение треклиста альбома
+	GetAlbumTracks(ctx context.Context, id uuid.UUID) ([]models.Track, error)
+	// Обновление информации об альбоме
+	UpdateAlbum(ctx context.Context, id uuid.UUID, upd models.Album) error
+	// Удаление альбома (песни альбома сохраняются)
+	DeleteAlbum(ctx context.Context, id uuid.UUID) error
+	// Добавление песни в альбом или изменение её позиции
+	SetAlbumTrack(ctx context.Context, albumId, songId uuid.UUID, pos models.TrackPosition) error
+	// Удаление песни из альбома
+	RemoveAlbumTrack(ctx context.Context, albumId, songId uuid.UUID) error
+
+	// Добавление автора или продюсера
+	CreatePerson(ctx context.Context, name string) (models.Person, error)
+	// Получение списка авторов и продюсеров
+	GetPeople(ctx context.Context, limit, offset int) ([]models.Person, error)
+	// Получение информации о конкретном человеке
+	GetPerson(ctx context.Context, id uuid.UUID) (models.Person, error)
+	// Получение песен, в создании которых участвовал человек
+	GetPersonSongs(ctx context.Context, id uuid.UUID, limit, offset int) ([]models.PersonSong, error)
+	// Добавление участника песни (человек создается при необходимости)
+	AddCredit(ctx context.Context, songId uuid.UUID, name, role string) (models.SongCredit, error)
+	// Удаление участника песни (в одной или во всех ролях)
+	RemoveCredit(ctx context.Context, songId, personId uuid.UUID, role *string) error
+
+	// Добавление жанра (с родительским жанром или без)
+	CreateGenre(ctx context.Context, name string, parent *string) (models.Genre, error)
+	// Получение всех жанров
+	GetGenres(ctx context.Context) ([]models.Genre, error)
+	// Удаление жанра (поджанры становятся жанрами верхнего уровня)
+	DeleteGenre(ctx context.Context, id uuid.UUID) error
+	// Получение жанров песни
+	GetSongGenres(ctx context.Context, songId uuid.UUID) ([]string, error)
+	// Замена жанров песни
+	SetSongGenres(ctx context.Context, songId uuid.UUID, genres []string) error
+	// Получение тегов песни
+	GetSongTags(ctx context.Context, songId uuid.UUID) ([]string, error)
+	// Замена тегов песни
+	SetSongTags(ctx context.Context, songId uuid.UUID, tags []string) error
+	// Получение количества песен по тегам среди песен, подходящих под фильтры
+	GetTagCounts(ctx context.Context, limit int, filter models.Filter) ([]models.TagCount, error)
+
+	// Получение ссылок песни
+	GetLinks(ctx context.Context, songId uuid.UUID) ([]models.SongLink, error)
+	// Добавление ссылки на песню; основная ссылка заменяет прежнюю
+	AddLink(ctx context.Context, songId uuid.UUID, link models.SongLink) (models.SongLink, error)
+	// Удаление ссылки на песню (кроме основной)
+	RemoveLink(ctx context.Context, songId, linkId uuid.UUID) error
+	// Получение ссылок, которые не прошли последнюю проверку
+	GetBrokenLinks(ctx context.Context, limit, offset int) ([]models.BrokenLink, error)
+
+	// Получение ревизий песни
+	GetRevisions(ctx context.Context, songId uuid.UUID, limit, offset int) (models.RevisionsPage, error)
+	// Получение ревизии песни с текстом
+	GetRevision(ctx context.Context, songId uuid.UUID, num int) (models.RevisionWithDetail, error)
+	// Сравнение двух ревизий песни по полям и куплетам
+	DiffRevisions(ctx context.Context, songId uuid.UUID, from, to int) (models.RevisionDiff, error)
+	// Восстановление песни из ревизии, возвращает номер новой ревизии
+	RestoreRevision(ctx context.Context, songId uuid.UUID, num int) (int, error)
+
+	// Получение песен из корзины
+	GetTrash(ctx context.Context, limit, offset int) (models.TrashPage, error)
+	// Восстановление песни из корзины
+	RestoreSong(ctx context.Context, id uuid.UUID) error
+
+	// Получение записей журнала аудита
+	GetAudit(ctx context.Context, limit, offset int, filter models.AuditFilter) (models.AuditPage, error)
+
+	// Статистика исходящих запросов к внешним сервисам по названию сервиса
+	GetOutboundStats(ctx context.Context) map[string]httpclient.Stats
+}
+
+// Источник сведений о песне для автозаполнения при добавлении. Реализуется songinfo.Client
+type SongInfoProvider interface {
+	Info(ctx context.Context, song models.Song) (songinfo.Info, error)
+	// статистика исходящих запросов к сервису
+	Stats() httpclient.Stats
 }
 
 // Service impl
 type MusicService struct {
 	repo repository.Repository
+	// nil - автозаполнение отключено
+	info SongInfoProvider
+
+	searchLang string
 }
 
-func NewMusicService(repo repository.Repository) *MusicService {
+func NewMusicService(repo repository.Repository, info SongInfoProvider, searchConf config.SearchConfig) *MusicService {
 	return &MusicService{
-		repo: repo,
+		repo:       repo,
+		info:       info,
+		searchLang: searchConf.SearchLanguage,
 	}
 }
 
-func (ms *MusicService) Create(ctx context.Context, song models.SongWithDetailPlain) error {
+func (ms *MusicService) Create(ctx context.Context, song models.SongWithDetailPlain) (uuid.UUID, error) {
+	meta, err := song.SongMeta.Normalize()
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+	song.SongMeta = meta
+
+	song, err = ms.enrich(ctx, song)
+	if err != nil {
+		return uuid.UUID{}, err
+	}
+
 	songSplit := song.Split()
-	return ms.repo.Create(ctx, songSplit)
+	return ms.repo.Create(ctx, songSplit, auditEntry(ctx, models.AuditCreate))
 
 }
 
-func (ms *MusicService) GetSongs(ctx context.Context, limit, offset int, filter models.Filter) ([]models.SongWithDetail, error) {
+func (ms *MusicService) GetSongs(ctx context.Context, limit, offset int, filter models.Filter) (models.SongsPage, error) {
 	return ms.repo.Read(ctx, limit, offset, filter)
 
 }
 
-func (ms *MusicService) GetText(ctx context.Context, limit, offset int, song models.Song) (string, error) {
-	verses, err := ms.repo.ReadText(ctx, limit, offset, song)
+func (ms *MusicService) GetSongsByCursor(ctx context.Context, limit int, cursor string, filter models.Filter) (models.SongsPage, error) {
+	return ms.repo.ReadByCursor(ctx, limit, cursor, filter)
+}
+
+func (ms *MusicService) GetId(ctx context.Context, song models.Song) (uuid.UUID, error) {
+	return ms.repo.ReadId(ctx, song)
+}
+
+func (ms *MusicService) GetSong(ctx context.Context, id uuid.UUID) (models.SongWithDetail, error) {
+	return ms.repo.ReadById(ctx, id)
+}
+
+func (ms *MusicService) GetText(ctx context.Context, limit, offset int, id uuid.UUID, filter models.TextFilter) (models.SongText, error) {
+	return ms.repo.ReadText(ctx, limit, offset, id, filter)
+}
+
+func (ms *MusicService) GetTextByCursor(ctx context.Context, limit int, cursor string, id uuid.UUID, filter models.TextFilter) (models.SongText, error) {
+	return ms.repo.ReadTextByCursor(ctx, limit, cursor, id, filter)
+}
+
+func (ms *MusicService) GetDetail(ctx context.Context, id uuid.UUID) (models.SongDetailWithCredits, error) {
+	detail, err := ms.repo.ReadDetail(ctx, id)
 	if err != nil {
-		return "", err
+		return models.SongDetailWithCredits{}, err
 	}
 
-	var res string
-	for _, verse := range verses {
-		res += fmt.Sprintf("%v\n", verse)
+	credits, err := ms.repo.ReadCredits(ctx, id)
+	if err != nil {
+		return models.SongDetailWithCredits{}, err
 	}
-	res = strings.TrimSuffix(res, "\n")
 
-	return res, nil
+	return models.SongDetailWithCredits{
+		SongDetail: detail,
+		Credits:    credits,
+	}, nil
 }
 
-func (ms *MusicService) GetDetail(ctx context.Context, song models.Song) (models.SongDetail, error) {
-	return ms.repo.ReadDetail(ctx, song)
+func (ms *MusicService) Delete(ctx context.Context, id uuid.UUID) error {
+	return ms.repo.Delete(ctx, id, auditEntry(ctx, models.AuditDelete))
+
 }
 
-func (ms *MusicService) Delete(ctx context.Context, song models.Song) error {
-	return ms.repo.Delete(ctx, song)
+func (ms *MusicService) Search(ctx context.Context, limit, offset int, text, lang string) (models.SearchPage, error) {
+	if lang == "" {
+		lang = ms.searchLang
+	}
 
+	return ms.repo.Search(ctx, limit, offset, text, lang)
 }
 
-func (ms *MusicService) Update(ctx context.Context, song models.Song, upd models.SongWithDetailPlain) error {
+func (ms *MusicService) Update(ctx context.Context, id uuid.UUID, upd models.SongWithDetailPlain) error {
+	meta, err := upd.SongMeta.Normalize()
+	if err != nil {
+		return err
+	}
+	upd.SongMeta = meta
+
+	if upd.Link == "" || upd.Text == "" {
+		return ErrIncompleteSong
+	}
+
 	updSplit := upd.Split()
-	return ms.repo.Update(ctx, song, updSplit)
+	return ms.repo.Update(ctx, id, updSplit, auditEntry(ctx, models.AuditUpdate))
+
+}
+
+func (ms *MusicService) Patch(ctx context.Context, id uuid.UUID, patch models.SongPatchPlain) error {
+	meta, err := patch.SongMetaPatch.Normalize()
+	if err != nil {
+		return err
+	}
+	patch.SongMetaPatch = meta
 
+	return ms.repo.Patch(ctx, id, patch.Split(), auditEntry(ctx, models.AuditUpdate))
 }
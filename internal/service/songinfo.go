@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/cutlery47/music-storage/internal/songinfo"
+	"github.com/cutlery47/music-storage/pkg/httpclient"
+)
+
+// после автозаполнения у песни по-прежнему нет текста или ссылки
+var ErrIncompleteSong = errors.New("song text and link are required: they weren't provided and couldn't be fetched from the song info service...")
+
+// Заполняет недостающие дату релиза, текст и ссылку сведениями из внешнего сервиса.
+// Явно переданные значения не перезаписываются
+func (ms *MusicService) enrich(ctx context.Context, song models.SongWithDetailPlain) (models.SongWithDetailPlain, error) {
+	if ms.info != nil && (song.ReleaseDate == nil || song.Text == "" || song.Link == "") {
+		info, err := ms.info.Info(ctx, song.Song)
+		// сервис ничего не знает о песне - проверяем то, что передано явно
+		if err != nil && !errors.Is(err, songinfo.ErrNotFound) {
+			// без сведений из сервиса песня все равно полная - дата релиза необязательна
+			if song.Text == "" || song.Link == "" {
+				return models.SongWithDetailPlain{}, err
+			}
+		}
+
+		if song.ReleaseDate == nil {
+			song.ReleaseDate = info.ReleaseDate
+		}
+		if song.Text == "" {
+			song.Text = info.Text
+		}
+		if song.Link == "" {
+			song.Link = info.Link
+		}
+	}
+
+	if song.Text == "" || song.Link == "" {
+		return models.SongWithDetailPlain{}, ErrIncompleteSong
+	}
+
+	return song, nil
+}
+
+// Пустой набор, если автозаполнение отключено
+func (ms *MusicService) GetOutboundStats(ctx context.Context) map[string]httpclient.Stats {
+	stats := map[string]httpclient.Stats{}
+	if ms.info != nil {
+		stats["songinfo"] = ms.info.Stats()
+	}
+
+	return stats
+}
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/cutlery47/music-storage/internal/songinfo"
+	"github.com/cutlery47/music-storage/pkg/httpclient"
+)
+
+type stubInfo struct {
+	info  songinfo.Info
+	err   error
+	calls int
+}
+
+func (s *stubInfo) Info(ctx context.Context, song models.Song) (songinfo.Info, error) {
+	s.calls++
+	return s.info, s.err
+}
+
+func (s *stubInfo) Stats() httpclient.Stats {
+	return httpclient.Stats{}
+}
+
+func newSong(text, link string) models.SongWithDetailPlain {
+	song := models.SongWithDetailPlain{Text: text}
+	song.GroupName = "Muse"
+	song.SongName = "Supermassive Black Hole"
+	song.Link = link
+	return song
+}
+
+func TestEnrichFillsMissing(t *testing.T) {
+	released := time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC)
+	info := &stubInfo{info: songinfo.Info{ReleaseDate: &released, Text: "fetched text", Link: "https://example.com/fetched"}}
+	ms := &MusicService{info: info}
+
+	song, err := ms.enrich(context.Background(), newSong("own text", ""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if song.Text != "own text" {
+		t.Errorf("text = %q, explicit value should be kept", song.Text)
+	}
+	if song.Link != "https://example.com/fetched" {
+		t.Errorf("link = %q, want fetched link", song.Link)
+	}
+	if song.ReleaseDate == nil || !song.ReleaseDate.Equal(released) {
+		t.Errorf("release date = %v, want %v", song.ReleaseDate, released)
+	}
+}
+
+func TestEnrichSkipsCompleteSong(t *testing.T) {
+	released := time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC)
+	info := &stubInfo{}
+	ms := &MusicService{info: info}
+
+	song := newSong("text", "https://example.com/own")
+	song.ReleaseDate = &released
+
+	if _, err := ms.enrich(context.Background(), song); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.calls != 0 {
+		t.Errorf("calls = %v, complete song shouldn't be looked up", info.calls)
+	}
+}
+
+func TestEnrichErrors(t *testing.T) {
+	for _, tc := range []struct {
+		name string
+		song models.SongWithDetailPlain
+		err  error
+		want error
+	}{
+		{"not found, incomplete", newSong("", "https://example.com/own"), songinfo.ErrNotFound, ErrIncompleteSong},
+		{"unavailable, incomplete", newSong("", "https://example.com/own"), songinfo.ErrUnavailable, songinfo.ErrUnavailable},
+		// без даты релиза песня все равно полная
+		{"unavailable, complete", newSong("text", "https://example.com/own"), songinfo.ErrUnavailable, nil},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			ms := &MusicService{info: &stubInfo{err: tc.err}}
+
+			_, err := ms.enrich(context.Background(), tc.song)
+			if !errors.Is(err, tc.want) || (tc.want == nil && err != nil) {
+				t.Errorf("err = %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestEnrichDisabled(t *testing.T) {
+	ms := &MusicService{}
+
+	if _, err := ms.enrich(context.Background(), newSong("", "")); !errors.Is(err, ErrIncompleteSong) {
+		t.Errorf("err = %v, want ErrIncompleteSong", err)
+	}
+}
@@ -0,0 +1,16 @@
+package service
+
+import (
+	"context"
+
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/google/uuid"
+)
+
+func (ms *MusicService) GetTrash(ctx context.Context, limit, offset int) (models.TrashPage, error) {
+	return ms.repo.ReadTrash(ctx, limit, offset)
+}
+
+func (ms *MusicService) RestoreSong(ctx context.Context, id uuid.UUID) error {
+	return ms.repo.RestoreSong(ctx, id, auditEntry(ctx, models.AuditRestore))
+}
@@ -0,0 +1,202 @@
+package songinfo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cutlery47/music-storage/internal/config"
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/cutlery47/music-storage/pkg/httpclient"
+)
+
+var (
+	// внешний сервис ничего не знает о песне
+	ErrNotFound = errors.New("song info wasn't found...")
+	// внешний сервис не ответил после всех повторных попыток
+	ErrUnavailable = errors.New("song info service is unavailable...")
+)
+
+const (
+	defaultTimeout    = 3 * time.Second
+	defaultRetries    = 2
+	defaultBackoff    = 200 * time.Millisecond
+	defaultMaxBackoff = 2 * time.Second
+)
+
+// форматы даты релиза, которые может вернуть внешний сервис
+var dateLayouts = []string{"02.01.2006", time.DateOnly}
+
+// Сведения о песне из внешнего сервиса. Пустые поля - сведений нет
+type Info struct {
+	ReleaseDate *time.Time
+	Text        string
+	Link        string
+}
+
+// тело ответа GET /info
+type infoResponse struct {
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+}
+
+// Клиент внешнего сервиса сведений о песнях (GET /info?group=&song=).
+// Запросы, завершившиеся сетевой ошибкой, ответом 429 или 5xx, повторяются с экспоненциальной задержкой.
+// Предохранитель, ограничение одновременных запросов и кэш обеспечивает httpclient.Client
+type Client struct {
+	baseURL string
+	client  *httpclient.Client
+
+	// количество повторных попыток после первой
+	retries    int
+	backoff    time.Duration
+	maxBackoff time.Duration
+}
+
+// client = nil - клиент с настройками по умолчанию
+func New(conf config.SongInfoConfig, client *httpclient.Client, opts ...Option) *Client {
+	if client == nil {
+		client = httpclient.New(httpclient.Timeout(defaultTimeout))
+	}
+
+	c := &Client{
+		baseURL:    strings.TrimSuffix(conf.SongInfoURL, "/"),
+		client:     client,
+		retries:    defaultRetries,
+		backoff:    defaultBackoff,
+		maxBackoff: defaultMaxBackoff,
+	}
+
+	if conf.SongInfoRetries >= 0 {
+		c.retries = conf.SongInfoRetries
+	}
+
+	if conf.SongInfoBackoff > 0 {
+		c.backoff = conf.SongInfoBackoff
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Получает сведения о песне. Возвращает ErrNotFound, если сервис не знает о песне,
+// и ErrUnavailable, если сервис не ответил после всех попыток
+func (c *Client) Info(ctx context.Context, song models.Song) (Info, error) {
+	params := url.Values{}
+	params.Set("group", song.GroupName)
+	params.Set("song", song.SongName)
+	endpoint := fmt.Sprintf("%v/info?%v", c.baseURL, params.Encode())
+
+	var lastErr error
+	for attempt := 0; attempt <= c.retries; attempt++ {
+		if attempt > 0 {
+			if err := sleep(ctx, c.delay(attempt)); err != nil {
+				return Info{}, err
+			}
+		}
+
+		info, retry, err := c.fetch(ctx, endpoint)
+		if err == nil {
+			return info, nil
+		}
+		if !retry {
+			return Info{}, err
+		}
+		lastErr = err
+	}
+
+	return Info{}, fmt.Errorf("%w: %v", ErrUnavailable, lastErr)
+}
+
+// Выполняет один запрос. retry = true, если запрос имеет смысл повторить
+func (c *Client) fetch(ctx context.Context, endpoint string) (info Info, retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return Info{}, false, fmt.Errorf("http.NewRequestWithContext: %v", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	switch {
+	// запрос отменен вызывающей стороной - повторять нет смысла
+	case err != nil && ctx.Err() != nil:
+		return Info{}, false, ctx.Err()
+	// сервис недавно отказывал или перегружен - повтор только добавит нагрузки
+	case errors.Is(err, httpclient.ErrCircuitOpen), errors.Is(err, httpclient.ErrBulkheadFull):
+		return Info{}, false, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	case err != nil:
+		return Info{}, true, fmt.Errorf("c.client.Do: %v", err)
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+	case resp.StatusCode == http.StatusNotFound:
+		return Info{}, false, ErrNotFound
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return Info{}, true, fmt.Errorf("unexpected status: %v", resp.StatusCode)
+	default:
+		return Info{}, false, fmt.Errorf("%w: unexpected status: %v", ErrUnavailable, resp.StatusCode)
+	}
+
+	body := infoResponse{}
+	if err := json.Unmarshal(resp.Body, &body); err != nil {
+		return Info{}, false, fmt.Errorf("%w: couldn't decode response: %v", ErrUnavailable, err)
+	}
+
+	return body.toInfo(), false, nil
+}
+
+// Статистика исходящих запросов к сервису
+func (c *Client) Stats() httpclient.Stats {
+	return c.client.Stats()
+}
+
+// Задержка перед повторной попыткой: backoff * 2^(attempt-1), не больше maxBackoff,
+// со случайным разбросом до половины задержки, чтобы повторные запросы не приходили одновременно
+func (c *Client) delay(attempt int) time.Duration {
+	delay := c.backoff << (attempt - 1)
+	if delay <= 0 || delay > c.maxBackoff {
+		delay = c.maxBackoff
+	}
+
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// Дата в неизвестном формате отбрасывается
+func (ir infoResponse) toInfo() Info {
+	info := Info{
+		Text: ir.Text,
+		Link: strings.TrimSpace(ir.Link),
+	}
+
+	for _, layout := range dateLayouts {
+		if parsed, err := time.Parse(layout, strings.TrimSpace(ir.ReleaseDate)); err == nil {
+			info.ReleaseDate = &parsed
+			break
+		}
+	}
+
+	return info
+}
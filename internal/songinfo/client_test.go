@@ -0,0 +1,139 @@
+package songinfo_test
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/cutlery47/music-storage/internal/config"
+	"github.com/cutlery47/music-storage/internal/models"
+	"github.com/cutlery47/music-storage/internal/songinfo"
+	"github.com/cutlery47/music-storage/internal/songinfo/songinfotest"
+)
+
+var song = models.Song{GroupName: "Muse", SongName: "Supermassive Black Hole"}
+
+func newClient(t *testing.T, mock *songinfotest.Mock, retries int) *songinfo.Client {
+	t.Helper()
+
+	srv := httptest.NewServer(mock)
+	t.Cleanup(srv.Close)
+
+	conf := config.SongInfoConfig{
+		SongInfoURL:     srv.URL,
+		SongInfoRetries: retries,
+	}
+
+	return songinfo.New(conf, nil, songinfo.Backoff(time.Millisecond, 5*time.Millisecond))
+}
+
+func TestInfo(t *testing.T) {
+	mock := songinfotest.NewMock()
+	mock.Add(song, "16.07.2006", "Ooh baby", " https://example.com/smbh ")
+
+	info, err := newClient(t, mock, 2).Info(context.Background(), song)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if info.ReleaseDate == nil || !info.ReleaseDate.Equal(time.Date(2006, 7, 16, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("release date = %v, want 2006-07-16", info.ReleaseDate)
+	}
+	if info.Text != "Ooh baby" {
+		t.Errorf("text = %q, want %q", info.Text, "Ooh baby")
+	}
+	if info.Link != "https://example.com/smbh" {
+		t.Errorf("link = %q, want trimmed link", info.Link)
+	}
+}
+
+func TestInfoNotFound(t *testing.T) {
+	mock := songinfotest.NewMock()
+
+	_, err := newClient(t, mock, 2).Info(context.Background(), song)
+	if !errors.Is(err, songinfo.ErrNotFound) {
+		t.Fatalf("err = %v, want ErrNotFound", err)
+	}
+
+	// 404 не повторяется
+	if got := mock.Requests(); got != 1 {
+		t.Errorf("requests = %v, want 1", got)
+	}
+}
+
+func TestInfoRetries(t *testing.T) {
+	mock := songinfotest.NewMock()
+	mock.Add(song, "2006-07-16", "Ooh baby", "https://example.com/smbh")
+	mock.FailNext(2)
+
+	info, err := newClient(t, mock, 2).Info(context.Background(), song)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if info.Text != "Ooh baby" {
+		t.Errorf("text = %q, want %q", info.Text, "Ooh baby")
+	}
+
+	if got := mock.Requests(); got != 3 {
+		t.Errorf("requests = %v, want 3", got)
+	}
+}
+
+func TestInfoRetriesExhausted(t *testing.T) {
+	mock := songinfotest.NewMock()
+	mock.Add(song, "2006-07-16", "Ooh baby", "https://example.com/smbh")
+	mock.FailNext(10)
+
+	_, err := newClient(t, mock, 2).Info(context.Background(), song)
+	if !errors.Is(err, songinfo.ErrUnavailable) {
+		t.Fatalf("err = %v, want ErrUnavailable", err)
+	}
+
+	if got := mock.Requests(); got != 3 {
+		t.Errorf("requests = %v, want 3", got)
+	}
+}
+
+func TestInfoNoRetries(t *testing.T) {
+	mock := songinfotest.NewMock()
+	mock.Add(song, "2006-07-16", "Ooh baby", "https://example.com/smbh")
+	mock.FailNext(1)
+
+	_, err := newClient(t, mock, 0).Info(context.Background(), song)
+	if !errors.Is(err, songinfo.ErrUnavailable) {
+		t.Fatalf("err = %v, want ErrUnavailable", err)
+	}
+
+	if got := mock.Requests(); got != 1 {
+		t.Errorf("requests = %v, want 1", got)
+	}
+}
+
+func TestInfoCanceledDuringBackoff(t *testing.T) {
+	mock := songinfotest.NewMock()
+	mock.FailNext(10)
+
+	srv := httptest.NewServer(mock)
+	t.Cleanup(srv.Close)
+
+	conf := config.SongInfoConfig{SongInfoURL: srv.URL, SongInfoRetries: 5}
+	client := songinfo.New(conf, nil, songinfo.Backoff(time.Hour, time.Hour))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := client.Info(ctx, song)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Info returned after %v, backoff should be interrupted by context", elapsed)
+	}
+	if got := mock.Requests(); got != 1 {
+		t.Errorf("requests = %v, want 1", got)
+	}
+}
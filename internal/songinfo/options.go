@@ -0,0 +1,13 @@
+package songinfo
+
+import "time"
+
+type Option func(*Client)
+
+// Задает начальную и максимальную задержку между повторными попытками
+func Backoff(backoff, maxBackoff time.Duration) Option {
+	return func(c *Client) {
+		c.backoff = backoff
+		c.maxBackoff = maxBackoff
+	}
+}
@@ -0,0 +1,118 @@
+package songinfotest
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/cutlery47/music-storage/internal/models"
+)
+
+// тело ответа GET /info
+type infoResponse struct {
+	ReleaseDate string `json:"releaseDate"`
+	Text        string `json:"text"`
+	Link        string `json:"link"`
+}
+
+// Имитация внешнего сервиса сведений о песнях для тестов (вместе с httptest.Server)
+// и локальной разработки (cmd/songinfo-mock). Отвечает на GET /info?group=&song= так же, как настоящий сервис
+type Mock struct {
+	mu    sync.Mutex
+	songs map[models.Song]infoResponse
+	// количество ближайших запросов, на которые мок ответит 503
+	failures int
+	// задержка перед каждым ответом
+	delay    time.Duration
+	requests int
+}
+
+func NewMock() *Mock {
+	return &Mock{
+		songs: map[models.Song]infoResponse{},
+	}
+}
+
+// Добавляет сведения о песне. releaseDate передается в формате сервиса: DD.MM.YYYY
+func (m *Mock) Add(song models.Song, releaseDate, text, link string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.songs[song] = infoResponse{
+		ReleaseDate: releaseDate,
+		Text:        text,
+		Link:        link,
+	}
+}
+
+// Следующие n запросов завершатся ответом 503
+func (m *Mock) FailNext(n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.failures = n
+}
+
+// Задержка перед каждым ответом, например, для проверки таймаутов
+func (m *Mock) SetDelay(delay time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.delay = delay
+}
+
+// Количество полученных запросов
+func (m *Mock) Requests() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.requests
+}
+
+func (m *Mock) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	m.requests++
+	delay := m.delay
+	fail := m.failures > 0
+	if fail {
+		m.failures--
+	}
+	m.mu.Unlock()
+
+	if delay > 0 {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if r.Method != http.MethodGet || r.URL.Path != "/info" {
+		http.NotFound(w, r)
+		return
+	}
+
+	if fail {
+		http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	params := r.URL.Query()
+	if !params.Has("group") || !params.Has("song") {
+		http.Error(w, "group and song should be provided", http.StatusBadRequest)
+		return
+	}
+
+	m.mu.Lock()
+	info, ok := m.songs[models.Song{GroupName: params.Get("group"), SongName: params.Get("song")}]
+	m.mu.Unlock()
+
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(info)
+}
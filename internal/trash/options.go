@@ -0,0 +1,12 @@
+package trash
+
+import "time"
+
+type Option func(*Purger)
+
+// Подменяет источник текущего времени
+func Clock(now func() time.Time) Option {
+	return func(p *Purger) {
+		p.now = now
+	}
+}
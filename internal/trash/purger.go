@@ -0,0 +1,92 @@
+package trash
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/cutlery47/music-storage/internal/config"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	defaultRetentionDays = 30
+	defaultInterval      = time.Hour
+)
+
+// Хранилище песен. Реализуется repository.MusicRepository
+type Store interface {
+	PurgeSongs(ctx context.Context, deletedBefore time.Time) (int64, error)
+}
+
+// Фоновая очистка корзины: раз в interval окончательно удаляет песни, пролежавшие в корзине дольше retention
+type Purger struct {
+	store  Store
+	errLog *logrus.Logger
+
+	retention time.Duration
+	interval  time.Duration
+
+	// источник текущего времени, подменяется опцией Clock
+	now func() time.Time
+}
+
+func New(store Store, conf config.TrashConfig, errLog *logrus.Logger, opts ...Option) *Purger {
+	p := &Purger{
+		store:     store,
+		errLog:    errLog,
+		retention: defaultRetentionDays * 24 * time.Hour,
+		interval:  defaultInterval,
+		now:       time.Now,
+	}
+
+	if conf.TrashRetentionDays > 0 {
+		p.retention = time.Duration(conf.TrashRetentionDays) * 24 * time.Hour
+	}
+
+	if conf.TrashPurgeInterval > 0 {
+		p.interval = conf.TrashPurgeInterval
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Запускает очистку раз в interval до отмены контекста. Первый проход выполняется сразу
+func (p *Purger) Run(ctx context.Context) {
+	logrus.Debug("running trash purger...")
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		purged, err := p.PurgeOnce(ctx)
+		switch {
+		case err != nil && !errors.Is(err, context.Canceled):
+			p.errLog.Error(fmt.Sprintf("trash purge failed: %v", err))
+		case purged > 0:
+			logrus.Debug(fmt.Sprintf("purged %v songs from trash", purged))
+		}
+
+		select {
+		case <-ctx.Done():
+			logrus.Debug("trash purger stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Один проход: окончательно удаляет устаревшие песни и возвращает их количество
+func (p *Purger) PurgeOnce(ctx context.Context) (int64, error) {
+	purged, err := p.store.PurgeSongs(ctx, p.now().Add(-p.retention))
+	if err != nil {
+		return 0, fmt.Errorf("p.store.PurgeSongs: %v", err)
+	}
+
+	return purged, nil
+}
@@ -1,4 +1,4 @@
-DROP TABLE IF EXISTS msuic_schema.songs_details CASCADE;
+DROP TABLE IF EXISTS music_schema.songs_details CASCADE;
 
 DROP TABLE IF EXISTS music_schema.songs_verses CASCADE;
 
@@ -0,0 +1,5 @@
+ALTER TABLE music_schema.songs
+    DROP CONSTRAINT IF EXISTS songs_group_id_song_name_key;
+
+ALTER TABLE music_schema.songs
+    ALTER COLUMN group_id DROP NOT NULL;
@@ -0,0 +1,6 @@
+-- Песня однозначно определяется группой и названием
+ALTER TABLE music_schema.songs
+    ALTER COLUMN group_id SET NOT NULL;
+
+ALTER TABLE music_schema.songs
+    ADD CONSTRAINT songs_group_id_song_name_key UNIQUE (group_id, song_name);
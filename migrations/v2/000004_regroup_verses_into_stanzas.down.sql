@@ -0,0 +1,32 @@
+-- Разбиваем куплеты обратно на строки, разделяя куплеты пустой строкой
+CREATE TEMPORARY TABLE lines ON COMMIT DROP AS
+SELECT
+    song_id,
+    ROW_NUMBER() OVER (PARTITION BY song_id ORDER BY verse_num, line_num) AS verse_num,
+    line AS verse
+FROM (
+    SELECT
+        sv.song_id,
+        sv.verse_num,
+        l.line,
+        l.line_num
+    FROM (
+        SELECT
+            song_id,
+            verse_num,
+            -- после каждого куплета, кроме последнего, добавляем пустую строку
+            CASE
+                WHEN verse_num < MAX(verse_num) OVER (PARTITION BY song_id) THEN verse || E'\n'
+                ELSE verse
+            END AS verse
+        FROM music_schema.songs_verses
+    ) AS sv,
+    LATERAL regexp_split_to_table(sv.verse, E'\n') WITH ORDINALITY AS l(line, line_num)
+) AS split;
+
+DELETE FROM music_schema.songs_verses;
+
+INSERT INTO music_schema.songs_verses
+(song_id, verse_num, verse)
+SELECT song_id, verse_num, verse
+FROM lines;
@@ -0,0 +1,30 @@
+-- Ранее каждая строка текста хранилась отдельным куплетом.
+-- Склеиваем строки в куплеты: границей куплета считается пустая строка
+CREATE TEMPORARY TABLE stanzas ON COMMIT DROP AS
+WITH lines AS (
+    SELECT
+        sv.song_id,
+        sv.verse_num,
+        rtrim(replace(COALESCE(sv.verse, ''), E'\r', ''), E' \t') AS line,
+        -- номер куплета = количество пустых строк до текущей
+        SUM(CASE WHEN btrim(COALESCE(sv.verse, ''), E' \t\r\n') = '' THEN 1 ELSE 0 END)
+            OVER (PARTITION BY sv.song_id ORDER BY sv.verse_num) AS stanza
+    FROM music_schema.songs_verses AS sv
+)
+SELECT
+    song_id,
+    ROW_NUMBER() OVER (PARTITION BY song_id ORDER BY stanza) AS verse_num,
+    verse
+FROM (
+    SELECT song_id, stanza, string_agg(line, E'\n' ORDER BY verse_num) AS verse
+    FROM lines
+    WHERE btrim(line, E' \t\n') <> ''
+    GROUP BY song_id, stanza
+) AS grouped;
+
+DELETE FROM music_schema.songs_verses;
+
+INSERT INTO music_schema.songs_verses
+(song_id, verse_num, verse)
+SELECT song_id, verse_num, verse
+FROM stanzas;
@@ -0,0 +1,8 @@
+-- Возвращаем метки секций в текст куплетов, чтобы не потерять разметку
+UPDATE music_schema.songs_verses
+SET verse = '[' || initcap(section) || E']\n' || verse
+WHERE section <> 'verse';
+
+ALTER TABLE music_schema.songs_verses
+    DROP COLUMN IF EXISTS repeat_of,
+    DROP COLUMN IF EXISTS section;
@@ -0,0 +1,121 @@
+-- Тип секции куплета и ссылка на повторяемый куплет
+ALTER TABLE music_schema.songs_verses
+    ADD COLUMN section VARCHAR(32) NOT NULL DEFAULT 'verse'
+        CHECK (section IN ('intro', 'verse', 'pre-chorus', 'chorus', 'bridge', 'hook', 'outro', 'other')),
+    ADD COLUMN repeat_of music_schema.pos_int;
+
+-- Заново разбиваем текст песен на куплеты по тем же правилам, что и lyrics.Parse:
+-- границей куплета считается пустая строка или строка-метка ([Chorus], [Verse 2: Artist], ...),
+-- пустые строки сразу после метки секцию не завершают
+CREATE TEMPORARY TABLE lines ON COMMIT DROP AS
+SELECT
+    t.song_id,
+    l.line_num,
+    rtrim(l.line, E' \t') AS line,
+    l.line ~ '^\s*$' AS blank,
+    substring(btrim(l.line, E' \t') FROM '^\[([^\[\]]+)\]$') AS label
+FROM (
+    SELECT
+        song_id,
+        replace(replace(string_agg(COALESCE(verse, ''), E'\n\n' ORDER BY verse_num), E'\r\n', E'\n'), E'\r', E'\n') AS text
+    FROM music_schema.songs_verses
+    GROUP BY song_id
+) AS t
+CROSS JOIN LATERAL regexp_split_to_table(t.text, E'\n') WITH ORDINALITY AS l(line, line_num);
+
+-- Номер части текста: каждая метка и каждая пустая строка (кроме идущих сразу после метки) начинают новую часть
+CREATE TEMPORARY TABLE parts ON COMMIT DROP AS
+WITH prev AS (
+    SELECT
+        l.*,
+        -- последняя непустая строка до текущей
+        MAX(CASE WHEN NOT l.blank THEN l.line_num END)
+            OVER (PARTITION BY l.song_id ORDER BY l.line_num ROWS BETWEEN UNBOUNDED PRECEDING AND 1 PRECEDING) AS prev_num
+    FROM lines AS l
+), numbered AS (
+    SELECT
+        p.*,
+        SUM(CASE WHEN p.label IS NOT NULL OR (p.blank AND pl.label IS NULL) THEN 1 ELSE 0 END)
+            OVER (PARTITION BY p.song_id ORDER BY p.line_num) AS part
+    FROM prev AS p
+    LEFT JOIN lines AS pl ON pl.song_id = p.song_id AND pl.line_num = p.prev_num
+)
+SELECT
+    song_id,
+    part,
+    -- метка разбирается так же, как в lyrics.ParseSection: без исполнителя после ":" и без номера или "x2" в конце
+    regexp_replace(
+        btrim(split_part(lower(MAX(label)), ':', 1), E' \t'),
+        '(\s+([xх]\s*)?\d+)+$', ''
+    ) AS label,
+    bool_or(label IS NOT NULL) AS labeled,
+    string_agg(line, E'\n' ORDER BY line_num) FILTER (WHERE NOT blank AND label IS NULL) AS verse
+FROM numbered
+GROUP BY song_id, part;
+
+UPDATE parts
+SET label = CASE
+    WHEN label IS NULL THEN 'verse'
+    WHEN label IN ('intro', 'вступление', 'интро') THEN 'intro'
+    WHEN label IN ('verse', 'куплет') THEN 'verse'
+    WHEN label IN ('pre-chorus', 'prechorus', 'pre chorus', 'предприпев', 'пред-припев') THEN 'pre-chorus'
+    WHEN label IN ('chorus', 'refrain', 'припев') THEN 'chorus'
+    WHEN label IN ('bridge', 'бридж') THEN 'bridge'
+    WHEN label IN ('hook', 'хук') THEN 'hook'
+    WHEN label IN ('outro', 'концовка', 'аутро') THEN 'outro'
+    ELSE 'other'
+END;
+
+-- Метка без текста - повтор последней секции того же типа; если такой секции не было, метка отбрасывается
+UPDATE parts AS p
+SET verse = (
+    SELECT s.verse
+    FROM parts AS s
+    WHERE s.song_id = p.song_id AND s.part < p.part AND s.label = p.label AND s.verse IS NOT NULL
+    ORDER BY s.part DESC
+    LIMIT 1
+)
+WHERE p.verse IS NULL AND p.labeled;
+
+CREATE TEMPORARY TABLE stanzas ON COMMIT DROP AS
+SELECT
+    song_id,
+    ROW_NUMBER() OVER (PARTITION BY song_id ORDER BY part) AS verse_num,
+    verse,
+    label AS section,
+    labeled,
+    NULL::INTEGER AS repeat_of
+FROM parts
+WHERE verse IS NOT NULL;
+
+-- Отмечаем повторы так же, как lyrics.markRepeats: каждый повтор ссылается на первое вхождение куплета,
+-- повтор без метки наследует секцию оригинала, а неразмеченный куплет, встречающийся несколько раз, считается припевом
+WITH firsts AS (
+    SELECT DISTINCT ON (song_id, verse)
+        song_id,
+        verse,
+        verse_num,
+        section,
+        labeled,
+        COUNT(*) OVER (PARTITION BY song_id, verse) AS cnt
+    FROM stanzas
+    ORDER BY song_id, verse, verse_num
+)
+UPDATE stanzas AS s
+SET
+    repeat_of = CASE WHEN s.verse_num > f.verse_num THEN f.verse_num END,
+    section = CASE
+        WHEN s.labeled THEN s.section
+        WHEN f.labeled THEN f.section
+        WHEN f.cnt > 1 THEN 'chorus'
+        ELSE 'verse'
+    END
+FROM firsts AS f
+WHERE s.song_id = f.song_id AND s.verse = f.verse;
+
+DELETE FROM music_schema.songs_verses;
+
+INSERT INTO music_schema.songs_verses
+(song_id, verse_num, verse, section, repeat_of)
+SELECT song_id, verse_num, verse, section, repeat_of
+FROM stanzas;
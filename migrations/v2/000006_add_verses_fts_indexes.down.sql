@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS music_schema.songs_verses_fts_english_idx;
+
+DROP INDEX IF EXISTS music_schema.songs_verses_fts_russian_idx;
+
+DROP INDEX IF EXISTS music_schema.songs_verses_fts_simple_idx;
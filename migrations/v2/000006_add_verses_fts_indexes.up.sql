@@ -0,0 +1,10 @@
+-- Индексы для полнотекстового поиска по куплетам.
+-- Для каждой поддерживаемой конфигурации свой индекс: запросы должны использовать то же выражение
+CREATE INDEX IF NOT EXISTS songs_verses_fts_english_idx
+    ON music_schema.songs_verses USING GIN (to_tsvector('english', verse));
+
+CREATE INDEX IF NOT EXISTS songs_verses_fts_russian_idx
+    ON music_schema.songs_verses USING GIN (to_tsvector('russian', verse));
+
+CREATE INDEX IF NOT EXISTS songs_verses_fts_simple_idx
+    ON music_schema.songs_verses USING GIN (to_tsvector('simple', verse));
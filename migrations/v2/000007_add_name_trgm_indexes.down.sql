@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS music_schema.groups_group_name_trgm_idx;
+
+DROP INDEX IF EXISTS music_schema.songs_song_name_trgm_idx;
+
+DROP INDEX IF EXISTS music_schema.groups_group_name_lower_idx;
+
+DROP INDEX IF EXISTS music_schema.songs_song_name_lower_idx;
@@ -0,0 +1,16 @@
+-- Нечеткий и частичный поиск по названиям групп и песен
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- триграммные индексы используются для операторов <% и ILIKE
+CREATE INDEX IF NOT EXISTS groups_group_name_trgm_idx
+    ON music_schema.groups USING GIN (group_name gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS songs_song_name_trgm_idx
+    ON music_schema.songs USING GIN (song_name gin_trgm_ops);
+
+-- для сравнения без учета регистра
+CREATE INDEX IF NOT EXISTS groups_group_name_lower_idx
+    ON music_schema.groups (lower(group_name));
+
+CREATE INDEX IF NOT EXISTS songs_song_name_lower_idx
+    ON music_schema.songs (lower(song_name));
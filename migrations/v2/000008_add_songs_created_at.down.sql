@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS music_schema.songs_details_released_at_idx;
+
+DROP INDEX IF EXISTS music_schema.songs_created_at_idx;
+
+ALTER TABLE music_schema.songs
+    DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,9 @@
+-- Время добавления песни, используется для сортировки
+ALTER TABLE music_schema.songs
+    ADD COLUMN IF NOT EXISTS created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX IF NOT EXISTS songs_created_at_idx
+    ON music_schema.songs (created_at);
+
+CREATE INDEX IF NOT EXISTS songs_details_released_at_idx
+    ON music_schema.songs_details (released_at);
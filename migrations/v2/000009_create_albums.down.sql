@@ -0,0 +1,5 @@
+DROP VIEW IF EXISTS music_schema.songs_details_resolved;
+
+DROP TABLE IF EXISTS music_schema.album_tracks;
+
+DROP TABLE IF EXISTS music_schema.albums;
@@ -0,0 +1,45 @@
+-- Таблица для хранения альбомов
+CREATE TABLE IF NOT EXISTS music_schema.albums(
+    id              music_schema.uuid_key       PRIMARY KEY,
+    group_id        UUID                        NOT NULL REFERENCES music_schema.groups(id) ON DELETE CASCADE,
+    title           music_schema.string,
+    released_at     date,
+    album_type      music_schema.string         DEFAULT 'LP',
+    created_at      TIMESTAMPTZ                 NOT NULL DEFAULT now(),
+
+    UNIQUE(group_id, title),
+    CHECK (album_type IN ('LP', 'EP', 'single', 'compilation'))
+);
+
+-- Треклисты альбомов. Одна песня может входить в несколько альбомов
+CREATE TABLE IF NOT EXISTS music_schema.album_tracks(
+    album_id        UUID                        NOT NULL REFERENCES music_schema.albums(id) ON DELETE CASCADE,
+    song_id         UUID                        NOT NULL REFERENCES music_schema.songs(id) ON DELETE CASCADE,
+    disc_num        music_schema.pos_int        NOT NULL DEFAULT 1,
+    track_num       music_schema.pos_int        NOT NULL,
+
+    PRIMARY KEY(album_id, song_id),
+    UNIQUE(album_id, disc_num, track_num)
+);
+
+CREATE INDEX IF NOT EXISTS album_tracks_song_id_idx
+    ON music_schema.album_tracks (song_id);
+
+-- Информация о песнях, в которой дата релиза без явного значения
+-- берется из самого раннего альбома, содержащего песню
+CREATE OR REPLACE VIEW music_schema.songs_details_resolved AS
+SELECT
+    sd.id,
+    sd.song_id,
+    COALESCE(
+        sd.released_at,
+        (
+            SELECT MIN(a.released_at)
+            FROM music_schema.album_tracks AS t
+            JOIN music_schema.albums AS a
+            ON a.id = t.album_id
+            WHERE t.song_id = sd.song_id
+        )
+    ) AS released_at,
+    sd.link
+FROM music_schema.songs_details AS sd;
@@ -0,0 +1,20 @@
+-- Исполнители песен с ролями. Основной исполнитель дублирует songs.group_id,
+-- приглашенные исполнители и авторы ремиксов хранятся только здесь
+CREATE TABLE IF NOT EXISTS music_schema.song_artists(
+    song_id         UUID                        NOT NULL REFERENCES music_schema.songs(id) ON DELETE CASCADE,
+    group_id        UUID                        NOT NULL REFERENCES music_schema.groups(id) ON DELETE CASCADE,
+    role            music_schema.string,
+    position        music_schema.pos_int,
+
+    PRIMARY KEY(song_id, group_id, role),
+    CHECK (role IN ('primary', 'featured', 'remixer'))
+);
+
+CREATE INDEX IF NOT EXISTS song_artists_group_id_idx
+    ON music_schema.song_artists (group_id);
+
+-- основные исполнители уже добавленных песен
+INSERT INTO music_schema.song_artists (song_id, group_id, role, position)
+SELECT s.id, s.group_id, 'primary', 1
+FROM music_schema.songs AS s
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS music_schema.song_credits;
+
+DROP TABLE IF EXISTS music_schema.people;
@@ -0,0 +1,20 @@
+-- Таблица для хранения авторов и продюсеров
+CREATE TABLE IF NOT EXISTS music_schema.people(
+    id              music_schema.uuid_key       PRIMARY KEY,
+    full_name       music_schema.string,
+
+    UNIQUE(full_name)
+);
+
+-- Участие людей в создании песен: авторы текста и музыки, продюсеры
+CREATE TABLE IF NOT EXISTS music_schema.song_credits(
+    song_id         UUID                        NOT NULL REFERENCES music_schema.songs(id) ON DELETE CASCADE,
+    person_id       UUID                        NOT NULL REFERENCES music_schema.people(id) ON DELETE CASCADE,
+    role            music_schema.string,
+
+    PRIMARY KEY(song_id, person_id, role),
+    CHECK (role IN ('lyricist', 'composer', 'producer'))
+);
+
+CREATE INDEX IF NOT EXISTS song_credits_person_id_idx
+    ON music_schema.song_credits (person_id);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS music_schema.song_tags;
+
+DROP TABLE IF EXISTS music_schema.song_genres;
+
+DROP TABLE IF EXISTS music_schema.genres;
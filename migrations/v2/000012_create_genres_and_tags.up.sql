@@ -0,0 +1,34 @@
+-- Жанры образуют иерархию: у поджанра (grunge) есть родительский жанр (rock).
+-- Названия жанров и тегов хранятся в нижнем регистре
+CREATE TABLE IF NOT EXISTS music_schema.genres(
+    id              music_schema.uuid_key       PRIMARY KEY,
+    name            music_schema.string,
+    parent_id       UUID                        REFERENCES music_schema.genres(id) ON DELETE SET NULL,
+
+    UNIQUE(name)
+);
+
+CREATE INDEX IF NOT EXISTS genres_parent_id_idx
+    ON music_schema.genres (parent_id);
+
+-- Жанры песен
+CREATE TABLE IF NOT EXISTS music_schema.song_genres(
+    song_id         UUID                        NOT NULL REFERENCES music_schema.songs(id) ON DELETE CASCADE,
+    genre_id        UUID                        NOT NULL REFERENCES music_schema.genres(id) ON DELETE CASCADE,
+
+    PRIMARY KEY(song_id, genre_id)
+);
+
+CREATE INDEX IF NOT EXISTS song_genres_genre_id_idx
+    ON music_schema.song_genres (genre_id);
+
+-- Произвольные теги песен
+CREATE TABLE IF NOT EXISTS music_schema.song_tags(
+    song_id         UUID                        NOT NULL REFERENCES music_schema.songs(id) ON DELETE CASCADE,
+    tag             music_schema.string,
+
+    PRIMARY KEY(song_id, tag)
+);
+
+CREATE INDEX IF NOT EXISTS song_tags_tag_idx
+    ON music_schema.song_tags (tag);
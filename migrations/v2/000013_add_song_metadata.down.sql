@@ -0,0 +1,33 @@
+-- CREATE OR REPLACE не умеет удалять столбцы представления, поэтому пересоздаем его
+DROP VIEW IF EXISTS music_schema.songs_details_resolved;
+
+CREATE VIEW music_schema.songs_details_resolved AS
+SELECT
+    sd.id,
+    sd.song_id,
+    COALESCE(
+        sd.released_at,
+        (
+            SELECT MIN(a.released_at)
+            FROM music_schema.album_tracks AS t
+            JOIN music_schema.albums AS a
+            ON a.id = t.album_id
+            WHERE t.song_id = sd.song_id
+        )
+    ) AS released_at,
+    sd.link
+FROM music_schema.songs_details AS sd;
+
+DROP INDEX IF EXISTS music_schema.songs_details_language_idx;
+
+DROP INDEX IF EXISTS music_schema.songs_details_duration_sec_idx;
+
+DROP INDEX IF EXISTS music_schema.songs_details_isrc_idx;
+
+ALTER TABLE music_schema.songs_details
+    DROP COLUMN IF EXISTS musical_key,
+    DROP COLUMN IF EXISTS bpm,
+    DROP COLUMN IF EXISTS explicit,
+    DROP COLUMN IF EXISTS language,
+    DROP COLUMN IF EXISTS isrc,
+    DROP COLUMN IF EXISTS duration_sec;
@@ -0,0 +1,42 @@
+-- Дополнительные сведения о песнях. NULL - значение неизвестно
+ALTER TABLE music_schema.songs_details
+    ADD COLUMN IF NOT EXISTS duration_sec   INTEGER     CHECK (duration_sec BETWEEN 1 AND 86400),
+    ADD COLUMN IF NOT EXISTS isrc           CHAR(12)    CHECK (isrc ~ '^[A-Z]{2}[A-Z0-9]{3}[0-9]{7}$'),
+    ADD COLUMN IF NOT EXISTS language       CHAR(2)     CHECK (language ~ '^[a-z]{2}$'),
+    ADD COLUMN IF NOT EXISTS explicit       BOOLEAN     NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS bpm            SMALLINT    CHECK (bpm BETWEEN 1 AND 999),
+    ADD COLUMN IF NOT EXISTS musical_key    VARCHAR(3)  CHECK (musical_key ~ '^[A-G][#b]?m?$');
+
+-- один ISRC соответствует одной записи
+CREATE UNIQUE INDEX IF NOT EXISTS songs_details_isrc_idx
+    ON music_schema.songs_details (isrc);
+
+CREATE INDEX IF NOT EXISTS songs_details_duration_sec_idx
+    ON music_schema.songs_details (duration_sec);
+
+CREATE INDEX IF NOT EXISTS songs_details_language_idx
+    ON music_schema.songs_details (language);
+
+-- новые столбцы добавляются в конец представления, поэтому достаточно CREATE OR REPLACE
+CREATE OR REPLACE VIEW music_schema.songs_details_resolved AS
+SELECT
+    sd.id,
+    sd.song_id,
+    COALESCE(
+        sd.released_at,
+        (
+            SELECT MIN(a.released_at)
+            FROM music_schema.album_tracks AS t
+            JOIN music_schema.albums AS a
+            ON a.id = t.album_id
+            WHERE t.song_id = sd.song_id
+        )
+    ) AS released_at,
+    sd.link,
+    sd.duration_sec,
+    sd.isrc,
+    sd.language,
+    sd.explicit,
+    sd.bpm,
+    sd.musical_key
+FROM music_schema.songs_details AS sd;
@@ -0,0 +1,42 @@
+DROP VIEW IF EXISTS music_schema.songs_details_resolved;
+
+ALTER TABLE music_schema.songs_details
+    ADD COLUMN IF NOT EXISTS link VARCHAR(256);
+
+-- возвращаем основные ссылки на место
+UPDATE music_schema.songs_details AS sd
+SET link = l.url
+FROM music_schema.song_links AS l
+WHERE l.song_id = sd.song_id AND l.link_type = 'primary';
+
+UPDATE music_schema.songs_details
+SET link = ''
+WHERE link IS NULL;
+
+ALTER TABLE music_schema.songs_details
+    ALTER COLUMN link TYPE music_schema.string;
+
+CREATE VIEW music_schema.songs_details_resolved AS
+SELECT
+    sd.id,
+    sd.song_id,
+    COALESCE(
+        sd.released_at,
+        (
+            SELECT MIN(a.released_at)
+            FROM music_schema.album_tracks AS t
+            JOIN music_schema.albums AS a
+            ON a.id = t.album_id
+            WHERE t.song_id = sd.song_id
+        )
+    ) AS released_at,
+    sd.link,
+    sd.duration_sec,
+    sd.isrc,
+    sd.language,
+    sd.explicit,
+    sd.bpm,
+    sd.musical_key
+FROM music_schema.songs_details AS sd;
+
+DROP TABLE IF EXISTS music_schema.song_links;
@@ -0,0 +1,61 @@
+-- Ссылки на песню. Основная ссылка (primary) у песни одна и отдается как songs_details_resolved.link
+CREATE TABLE IF NOT EXISTS music_schema.song_links(
+    id              music_schema.uuid_key       PRIMARY KEY,
+    song_id         UUID                        NOT NULL REFERENCES music_schema.songs(id) ON DELETE CASCADE,
+    url             music_schema.string,
+    link_type       music_schema.string,
+    -- код страны ISO 3166-1, NULL - ссылка доступна везде
+    region          CHAR(2)                     CHECK (region ~ '^[A-Z]{2}$'),
+    created_at      TIMESTAMPTZ                 NOT NULL DEFAULT now(),
+
+    UNIQUE(song_id, link_type, url),
+    CHECK (link_type IN ('primary', 'youtube', 'streaming', 'lyrics', 'purchase'))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS song_links_primary_idx
+    ON music_schema.song_links (song_id)
+    WHERE link_type = 'primary';
+
+-- переносим существующие ссылки
+INSERT INTO music_schema.song_links
+(song_id, url, link_type)
+SELECT sd.song_id, sd.link, 'primary'
+FROM music_schema.songs_details AS sd
+WHERE sd.song_id IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+-- представление зависит от songs_details.link, поэтому пересоздаем его до удаления столбца
+DROP VIEW IF EXISTS music_schema.songs_details_resolved;
+
+ALTER TABLE music_schema.songs_details
+    DROP COLUMN IF EXISTS link;
+
+CREATE VIEW music_schema.songs_details_resolved AS
+SELECT
+    sd.id,
+    sd.song_id,
+    COALESCE(
+        sd.released_at,
+        (
+            SELECT MIN(a.released_at)
+            FROM music_schema.album_tracks AS t
+            JOIN music_schema.albums AS a
+            ON a.id = t.album_id
+            WHERE t.song_id = sd.song_id
+        )
+    ) AS released_at,
+    COALESCE(
+        (
+            SELECT l.url::text
+            FROM music_schema.song_links AS l
+            WHERE l.song_id = sd.song_id AND l.link_type = 'primary'
+        ),
+        ''
+    ) AS link,
+    sd.duration_sec,
+    sd.isrc,
+    sd.language,
+    sd.explicit,
+    sd.bpm,
+    sd.musical_key
+FROM music_schema.songs_details AS sd;
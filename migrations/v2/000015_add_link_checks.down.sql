@@ -0,0 +1,10 @@
+DROP INDEX IF EXISTS music_schema.song_links_broken_idx;
+
+DROP INDEX IF EXISTS music_schema.song_links_checked_at_idx;
+
+ALTER TABLE music_schema.song_links
+    DROP COLUMN IF EXISTS broken,
+    DROP COLUMN IF EXISTS check_error,
+    DROP COLUMN IF EXISTS redirect_url,
+    DROP COLUMN IF EXISTS status_code,
+    DROP COLUMN IF EXISTS checked_at;
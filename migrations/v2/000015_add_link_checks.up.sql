@@ -0,0 +1,17 @@
+-- Результаты последней проверки ссылок фоновым процессом. NULL - ссылка еще не проверялась
+ALTER TABLE music_schema.song_links
+    ADD COLUMN IF NOT EXISTS checked_at     TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS status_code    INTEGER,
+    -- адрес, на который ведет ссылка после перенаправлений
+    ADD COLUMN IF NOT EXISTS redirect_url   TEXT,
+    -- ошибка запроса, если ответ не был получен
+    ADD COLUMN IF NOT EXISTS check_error    TEXT,
+    ADD COLUMN IF NOT EXISTS broken         BOOLEAN     NOT NULL DEFAULT false;
+
+-- ссылки выбираются на проверку начиная с давно не проверявшихся
+CREATE INDEX IF NOT EXISTS song_links_checked_at_idx
+    ON music_schema.song_links (checked_at NULLS FIRST);
+
+CREATE INDEX IF NOT EXISTS song_links_broken_idx
+    ON music_schema.song_links (checked_at)
+    WHERE broken;
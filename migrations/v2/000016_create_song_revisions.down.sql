@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS music_schema.song_revision_verses;
+DROP TABLE IF EXISTS music_schema.song_revisions;
@@ -0,0 +1,57 @@
+-- Неизменяемые снимки песни: детали и полный текст на момент каждого создания/обновления
+CREATE TABLE IF NOT EXISTS music_schema.song_revisions(
+    id              music_schema.uuid_key       PRIMARY KEY,
+    song_id         UUID                        NOT NULL REFERENCES music_schema.songs(id) ON DELETE CASCADE,
+    -- порядковый номер ревизии песни, начиная с 1
+    revision_num    music_schema.pos_int        NOT NULL,
+    group_name      music_schema.string,
+    song_name       music_schema.string,
+    -- дата релиза, указанная явно (без даты альбома)
+    released_at     date,
+    link            music_schema.string,
+    duration_sec    INTEGER,
+    isrc            CHAR(12),
+    language        CHAR(2),
+    explicit        BOOLEAN                     NOT NULL DEFAULT false,
+    bpm             SMALLINT,
+    musical_key     VARCHAR(3),
+    -- кто внес изменение, NULL - неизвестно
+    author          VARCHAR(256),
+    -- номер ревизии, из которой восстановлена данная
+    restored_from   INTEGER,
+    created_at      TIMESTAMPTZ                 NOT NULL DEFAULT now(),
+
+    UNIQUE(song_id, revision_num)
+);
+
+CREATE TABLE IF NOT EXISTS music_schema.song_revision_verses(
+    revision_id     UUID                        NOT NULL REFERENCES music_schema.song_revisions(id) ON DELETE CASCADE,
+    verse_num       music_schema.pos_int,
+    section         VARCHAR(32)                 NOT NULL DEFAULT 'verse',
+    verse           text,
+    repeat_of       music_schema.pos_int,
+
+    PRIMARY KEY(revision_id, verse_num)
+);
+
+-- текущее состояние существующих песен становится их первой ревизией
+INSERT INTO music_schema.song_revisions
+(song_id, revision_num, group_name, song_name, released_at, link, duration_sec, isrc, language, explicit, bpm, musical_key)
+SELECT
+    s.id, 1, g.group_name, s.song_name, sd.released_at,
+    COALESCE((SELECT l.url::text FROM music_schema.song_links AS l WHERE l.song_id = s.id AND l.link_type = 'primary'), ''),
+    sd.duration_sec, sd.isrc, sd.language, sd.explicit, sd.bpm, sd.musical_key
+FROM music_schema.songs AS s
+JOIN music_schema.groups AS g
+ON g.id = s.group_id
+JOIN music_schema.songs_details AS sd
+ON sd.song_id = s.id
+ON CONFLICT DO NOTHING;
+
+INSERT INTO music_schema.song_revision_verses
+(revision_id, verse_num, section, verse, repeat_of)
+SELECT r.id, sv.verse_num, sv.section, sv.verse, sv.repeat_of
+FROM music_schema.song_revisions AS r
+JOIN music_schema.songs_verses AS sv
+ON sv.song_id = r.song_id
+WHERE r.revision_num = 1;
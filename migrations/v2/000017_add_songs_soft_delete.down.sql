@@ -0,0 +1,12 @@
+-- без корзины песни в ней удаляются окончательно
+DELETE FROM music_schema.songs
+WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS music_schema.songs_deleted_at_idx;
+DROP INDEX IF EXISTS music_schema.songs_group_id_song_name_idx;
+
+ALTER TABLE music_schema.songs
+    ADD CONSTRAINT songs_group_id_song_name_key UNIQUE (group_id, song_name);
+
+ALTER TABLE music_schema.songs
+    DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,15 @@
+-- Удаленные песни попадают в корзину и окончательно удаляются фоновой очисткой. NULL - песня не удалена
+ALTER TABLE music_schema.songs
+    ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+-- песня в корзине не мешает добавить песню с тем же названием
+ALTER TABLE music_schema.songs
+    DROP CONSTRAINT IF EXISTS songs_group_id_song_name_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS songs_group_id_song_name_idx
+    ON music_schema.songs (group_id, song_name)
+    WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS songs_deleted_at_idx
+    ON music_schema.songs (deleted_at)
+    WHERE deleted_at IS NOT NULL;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS music_schema.audit_log;
@@ -0,0 +1,26 @@
+-- Журнал изменений песен. Записи не ссылаются на песни, чтобы пережить их окончательное удаление
+CREATE TABLE IF NOT EXISTS music_schema.audit_log(
+    id              music_schema.uuid_key       PRIMARY KEY,
+    -- автор изменения, NULL - неизвестен
+    actor           VARCHAR(256),
+    action          VARCHAR(32)                 NOT NULL,
+    entity_type     VARCHAR(32)                 NOT NULL,
+    entity_id       UUID                        NOT NULL,
+    -- состояние сущности до и после изменения
+    before          JSONB,
+    after           JSONB,
+    request_id      TEXT,
+    ip              TEXT,
+    created_at      TIMESTAMPTZ                 NOT NULL DEFAULT now(),
+
+    CHECK (action IN ('create', 'update', 'delete', 'restore'))
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx
+    ON music_schema.audit_log (created_at DESC);
+
+CREATE INDEX IF NOT EXISTS audit_log_entity_idx
+    ON music_schema.audit_log (entity_type, entity_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS audit_log_actor_idx
+    ON music_schema.audit_log (actor, created_at DESC);
@@ -0,0 +1,9 @@
+-- ISRC, занятый с тех пор другой песней, не восстанавливается
+UPDATE music_schema.songs_details AS sd
+SET isrc = sd.deleted_isrc
+WHERE
+sd.deleted_isrc IS NOT NULL
+AND NOT EXISTS (SELECT 1 FROM music_schema.songs_details AS other WHERE other.isrc = sd.deleted_isrc);
+
+ALTER TABLE music_schema.songs_details
+    DROP COLUMN IF EXISTS deleted_isrc;
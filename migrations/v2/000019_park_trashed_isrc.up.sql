@@ -0,0 +1,9 @@
+-- ISRC песни в корзине. Уникальный индекс по isrc не может учитывать songs.deleted_at,
+-- поэтому при удалении ISRC переносится сюда и возвращается при восстановлении
+ALTER TABLE music_schema.songs_details
+    ADD COLUMN IF NOT EXISTS deleted_isrc   CHAR(12);
+
+UPDATE music_schema.songs_details AS sd
+SET deleted_isrc = sd.isrc, isrc = NULL
+FROM music_schema.songs AS s
+WHERE s.id = sd.song_id AND s.deleted_at IS NOT NULL AND sd.isrc IS NOT NULL;
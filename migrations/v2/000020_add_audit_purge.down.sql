@@ -0,0 +1,8 @@
+DELETE FROM music_schema.audit_log
+WHERE action = 'purge' OR entity_type <> 'song';
+
+ALTER TABLE music_schema.audit_log
+    DROP CONSTRAINT IF EXISTS audit_log_entity_type_check,
+    DROP CONSTRAINT IF EXISTS audit_log_action_check,
+    ADD CONSTRAINT audit_log_action_check
+        CHECK (action IN ('create', 'update', 'delete', 'restore'));
@@ -0,0 +1,7 @@
+-- Журнал аудита охватывает альбомы, группы, людей и жанры, а также окончательное удаление песен из корзины
+ALTER TABLE music_schema.audit_log
+    DROP CONSTRAINT IF EXISTS audit_log_action_check,
+    ADD CONSTRAINT audit_log_action_check
+        CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge')),
+    ADD CONSTRAINT audit_log_entity_type_check
+        CHECK (entity_type IN ('song', 'album', 'group', 'person', 'genre'));
@@ -0,0 +1,136 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+type state int
+
+const (
+	// запросы проходят, отказы подсчитываются
+	stateClosed state = iota
+	// запросы отклоняются до истечения openTimeout
+	stateOpen
+	// пропускается один пробный запрос: успех замыкает цепь, отказ снова размыкает
+	stateHalfOpen
+)
+
+func (s state) String() string {
+	switch s {
+	case stateOpen:
+		return "open"
+	case stateHalfOpen:
+		return "half-open"
+	default:
+		return "closed"
+	}
+}
+
+type outcome int
+
+const (
+	outcomeSuccess outcome = iota
+	outcomeFailure
+	// результат не говорит о состоянии удаленного сервиса (например, запрос отменен)
+	outcomeIgnored
+)
+
+// Предохранитель: после threshold отказов подряд перестает пропускать запросы на openTimeout
+type breaker struct {
+	mu sync.Mutex
+
+	state    state
+	failures int
+	openedAt time.Time
+	// пробный запрос в полуоткрытом состоянии уже выполняется
+	probing bool
+
+	// 0 - предохранитель отключен
+	threshold   int
+	openTimeout time.Duration
+	now         func() time.Time
+}
+
+func newBreaker(threshold int, openTimeout time.Duration, now func() time.Time) *breaker {
+	return &breaker{
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		now:         now,
+	}
+}
+
+func (b *breaker) allow() bool {
+	if b.threshold <= 0 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateOpen:
+		if b.now().Sub(b.openedAt) < b.openTimeout {
+			return false
+		}
+		b.state = stateHalfOpen
+		b.probing = true
+		return true
+	case stateHalfOpen:
+		if b.probing {
+			return false
+		}
+		b.probing = true
+		return true
+	default:
+		return true
+	}
+}
+
+func (b *breaker) record(res outcome) {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case stateHalfOpen:
+		b.probing = false
+		switch res {
+		case outcomeSuccess:
+			b.state = stateClosed
+			b.failures = 0
+		case outcomeFailure:
+			b.open()
+		}
+	case stateClosed:
+		switch res {
+		case outcomeSuccess:
+			b.failures = 0
+		case outcomeFailure:
+			b.failures++
+			if b.failures >= b.threshold {
+				b.open()
+			}
+		}
+	}
+}
+
+func (b *breaker) open() {
+	b.state = stateOpen
+	b.openedAt = b.now()
+	b.failures = 0
+}
+
+// Текущее состояние с учетом истекшего openTimeout
+func (b *breaker) current() state {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == stateOpen && b.now().Sub(b.openedAt) >= b.openTimeout {
+		return stateHalfOpen
+	}
+	return b.state
+}
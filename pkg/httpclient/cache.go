@@ -0,0 +1,94 @@
+package httpclient
+
+import (
+	"sync"
+	"time"
+)
+
+type cacheEntry struct {
+	resp    Response
+	expires time.Time
+}
+
+// Кэш ответов с ограниченным временем жизни и количеством записей
+type cache struct {
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+
+	ttl  time.Duration
+	size int
+	now  func() time.Time
+}
+
+func newCache(ttl time.Duration, size int, now func() time.Time) *cache {
+	return &cache{
+		entries: map[string]cacheEntry{},
+		ttl:     ttl,
+		size:    size,
+		now:     now,
+	}
+}
+
+func (c *cache) get(key string) (Response, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return Response{}, false
+	}
+
+	if !c.now().Before(entry.expires) {
+		delete(c.entries, key)
+		return Response{}, false
+	}
+
+	return entry.resp, true
+}
+
+func (c *cache) set(key string, resp Response) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= c.size {
+		c.evict()
+	}
+
+	c.entries[key] = cacheEntry{
+		resp:    resp,
+		expires: c.now().Add(c.ttl),
+	}
+}
+
+// Удаляет устаревшие записи, а если таких нет - произвольную запись
+func (c *cache) evict() {
+	now := c.now()
+	for key, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, key)
+		}
+	}
+
+	if len(c.entries) < c.size {
+		return
+	}
+
+	for key := range c.entries {
+		delete(c.entries, key)
+		return
+	}
+}
+
+func (c *cache) purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries = map[string]cacheEntry{}
+}
+
+func (c *cache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return len(c.entries)
+}
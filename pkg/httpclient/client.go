@@ -0,0 +1,216 @@
+package httpclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+var (
+	// предохранитель разомкнут: удаленный сервис недавно отвечал ошибками
+	ErrCircuitOpen = errors.New("circuit breaker is open...")
+	// превышено количество одновременных запросов
+	ErrBulkheadFull = errors.New("too many concurrent outbound requests...")
+	// тело ответа больше допустимого
+	ErrBodyTooLarge = errors.New("response body is too large...")
+)
+
+const (
+	defaultTimeout          = 3 * time.Second
+	defaultFailureThreshold = 5
+	defaultOpenTimeout      = 30 * time.Second
+	defaultMaxConcurrent    = 10
+	defaultQueueTimeout     = 500 * time.Millisecond
+	defaultCacheSize        = 1000
+	defaultMaxBodySize      = 1 << 20
+)
+
+// Ответ удаленного сервиса с полностью прочитанным телом.
+// Ответы из кэша общие для всех вызывающих, поэтому Header и Body нельзя изменять
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Http-клиент для исходящих запросов к внешним сервисам.
+// Ограничивает количество одновременных запросов, размыкает цепь после серии ошибок,
+// кэширует ответы на GET-запросы и собирает статистику
+type Client struct {
+	client *http.Client
+	// таймаут одного запроса, включая чтение тела
+	timeout time.Duration
+
+	breaker *breaker
+	// семафор одновременных запросов
+	bulkhead chan struct{}
+	// сколько запрос может ждать свободного места
+	queueTimeout time.Duration
+	// nil - кэширование отключено
+	cache *cache
+
+	maxBodySize int64
+	metrics     metrics
+	now         func() time.Time
+}
+
+func New(opts ...Option) *Client {
+	c := &Client{
+		client:       &http.Client{},
+		timeout:      defaultTimeout,
+		queueTimeout: defaultQueueTimeout,
+		maxBodySize:  defaultMaxBodySize,
+		now:          time.Now,
+	}
+	c.breaker = newBreaker(defaultFailureThreshold, defaultOpenTimeout, c.clock)
+	c.bulkhead = make(chan struct{}, defaultMaxConcurrent)
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+// Выполняет запрос. Ответы 5xx и 429 возвращаются без ошибки, но считаются отказом удаленного сервиса.
+// Возвращает ErrCircuitOpen, если цепь разомкнута, и ErrBulkheadFull, если не дождался свободного места
+func (c *Client) Do(req *http.Request) (Response, error) {
+	c.metrics.requests.Add(1)
+
+	cacheable := c.cache != nil && req.Method == http.MethodGet
+	key := req.URL.String()
+	if cacheable {
+		if resp, ok := c.cache.get(key); ok {
+			c.metrics.cacheHits.Add(1)
+			return resp, nil
+		}
+		c.metrics.cacheMisses.Add(1)
+	}
+
+	if err := c.acquire(req); err != nil {
+		return Response{}, err
+	}
+	defer c.release()
+
+	if !c.breaker.allow() {
+		c.metrics.circuitRejections.Add(1)
+		return Response{}, ErrCircuitOpen
+	}
+
+	start := c.now()
+	resp, err := c.do(req)
+	c.metrics.observe(c.now().Sub(start))
+
+	switch {
+	// запрос отменен вызывающей стороной - удаленный сервис не виноват
+	case err != nil && req.Context().Err() != nil:
+		c.breaker.record(outcomeIgnored)
+		return Response{}, err
+	case err != nil:
+		c.metrics.failures.Add(1)
+		c.breaker.record(outcomeFailure)
+		return Response{}, err
+	case isFailure(resp.StatusCode):
+		c.metrics.failures.Add(1)
+		c.breaker.record(outcomeFailure)
+		return resp, nil
+	}
+
+	c.breaker.record(outcomeSuccess)
+	if cacheable && isCacheable(resp.StatusCode) {
+		c.cache.set(key, resp)
+	}
+
+	return resp, nil
+}
+
+func (c *Client) do(req *http.Request) (Response, error) {
+	if c.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return Response{}, fmt.Errorf("c.client.Do: %v", err)
+	}
+	defer resp.Body.Close()
+
+	// читаем на байт больше лимита, чтобы отличить тело ровно лимитного размера от слишком большого
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.maxBodySize+1))
+	if err != nil {
+		return Response{}, fmt.Errorf("io.ReadAll: %v", err)
+	}
+	if int64(len(body)) > c.maxBodySize {
+		return Response{}, ErrBodyTooLarge
+	}
+
+	return Response{
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	}, nil
+}
+
+// Занимает место в bulkhead, ожидая не дольше queueTimeout
+func (c *Client) acquire(req *http.Request) error {
+	select {
+	case c.bulkhead <- struct{}{}:
+		return nil
+	default:
+	}
+
+	timer := time.NewTimer(c.queueTimeout)
+	defer timer.Stop()
+
+	select {
+	case c.bulkhead <- struct{}{}:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	case <-timer.C:
+		c.metrics.bulkheadRejections.Add(1)
+		return ErrBulkheadFull
+	}
+}
+
+func (c *Client) release() {
+	<-c.bulkhead
+}
+
+// Сбрасывает кэш, например, после изменения данных во внешнем сервисе
+func (c *Client) Purge() {
+	if c.cache != nil {
+		c.cache.purge()
+	}
+}
+
+func (c *Client) Stats() Stats {
+	stats := c.metrics.snapshot()
+	stats.State = c.breaker.current().String()
+	stats.InFlight = len(c.bulkhead)
+	if c.cache != nil {
+		stats.CacheEntries = c.cache.len()
+	}
+
+	return stats
+}
+
+// Через clock предохранитель и кэш видят подмененное опцией Clock время
+func (c *Client) clock() time.Time {
+	return c.now()
+}
+
+// отказ удаленного сервиса: перегрузка или внутренняя ошибка
+func isFailure(status int) bool {
+	return status == http.StatusTooManyRequests || status >= 500
+}
+
+// кэшируются успешные ответы и ответы "не найдено"
+func isCacheable(status int) bool {
+	return status == http.StatusOK || status == http.StatusNotFound
+}
@@ -0,0 +1,201 @@
+package httpclient_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/cutlery47/music-storage/pkg/httpclient"
+)
+
+// управляемые часы
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func newClock() *clock {
+	return &clock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// сервер, отвечающий status и считающий запросы
+func newServer(t *testing.T, status *atomic.Int32, requests *atomic.Int32) *httptest.Server {
+	t.Helper()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(int(status.Load()))
+	}))
+	t.Cleanup(srv.Close)
+
+	return srv
+}
+
+func get(t *testing.T, c *httpclient.Client, url string) (httpclient.Response, error) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, url, nil)
+	if err != nil {
+		t.Fatalf("http.NewRequestWithContext: %v", err)
+	}
+
+	return c.Do(req)
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	var status, requests atomic.Int32
+	status.Store(http.StatusServiceUnavailable)
+	srv := newServer(t, &status, &requests)
+
+	clk := newClock()
+	c := httpclient.New(
+		httpclient.HTTPClient(srv.Client()),
+		httpclient.Clock(clk.Now),
+		httpclient.CircuitBreaker(3, time.Minute),
+	)
+
+	for range 3 {
+		resp, err := get(t, c, srv.URL)
+		if err != nil || resp.StatusCode != http.StatusServiceUnavailable {
+			t.Fatalf("got %v, %v; want 503 without error", resp.StatusCode, err)
+		}
+	}
+
+	// цепь разомкнута - запрос не доходит до сервера
+	if _, err := get(t, c, srv.URL); !errors.Is(err, httpclient.ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen", err)
+	}
+	if got := requests.Load(); got != 3 {
+		t.Errorf("requests = %v, want 3", got)
+	}
+	if state := c.Stats().State; state != "open" {
+		t.Errorf("state = %v, want open", state)
+	}
+
+	// по истечении openTimeout пропускается пробный запрос; неудачный снова размыкает цепь
+	clk.Advance(time.Minute)
+	if _, err := get(t, c, srv.URL); err != nil {
+		t.Fatalf("probe: unexpected error: %v", err)
+	}
+	if _, err := get(t, c, srv.URL); !errors.Is(err, httpclient.ErrCircuitOpen) {
+		t.Fatalf("err = %v, want ErrCircuitOpen after failed probe", err)
+	}
+
+	// успешный пробный запрос замыкает цепь
+	status.Store(http.StatusOK)
+	clk.Advance(time.Minute)
+	for range 2 {
+		if resp, err := get(t, c, srv.URL); err != nil || resp.StatusCode != http.StatusOK {
+			t.Fatalf("got %v, %v; want 200 without error", resp.StatusCode, err)
+		}
+	}
+	if state := c.Stats().State; state != "closed" {
+		t.Errorf("state = %v, want closed", state)
+	}
+
+	stats := c.Stats()
+	if stats.Failures != 4 || stats.CircuitRejections != 2 {
+		t.Errorf("failures = %v, rejections = %v; want 4 and 2", stats.Failures, stats.CircuitRejections)
+	}
+}
+
+func TestBulkhead(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		started <- struct{}{}
+		<-release
+	}))
+	t.Cleanup(srv.Close)
+	t.Cleanup(func() { close(release) })
+
+	c := httpclient.New(httpclient.Bulkhead(1, 10*time.Millisecond))
+
+	done := make(chan error)
+	go func() {
+		_, err := get(t, c, srv.URL)
+		done <- err
+	}()
+	<-started
+
+	// единственное место занято, второй запрос не дожидается его освобождения
+	if _, err := get(t, c, srv.URL); !errors.Is(err, httpclient.ErrBulkheadFull) {
+		t.Fatalf("err = %v, want ErrBulkheadFull", err)
+	}
+	if got := c.Stats().BulkheadRejections; got != 1 {
+		t.Errorf("bulkhead rejections = %v, want 1", got)
+	}
+
+	release <- struct{}{}
+	if err := <-done; err != nil {
+		t.Fatalf("first request: unexpected error: %v", err)
+	}
+}
+
+func TestCacheTTL(t *testing.T) {
+	var status, requests atomic.Int32
+	status.Store(http.StatusOK)
+	srv := newServer(t, &status, &requests)
+
+	clk := newClock()
+	c := httpclient.New(
+		httpclient.Clock(clk.Now),
+		httpclient.Cache(time.Minute, 10),
+	)
+
+	for range 2 {
+		if _, err := get(t, c, srv.URL); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := requests.Load(); got != 1 {
+		t.Errorf("requests = %v, want 1: second response should come from cache", got)
+	}
+
+	clk.Advance(time.Minute)
+	if _, err := get(t, c, srv.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %v, want 2: expired response shouldn't be used", got)
+	}
+
+	stats := c.Stats()
+	if stats.CacheHits != 1 || stats.CacheMisses != 2 {
+		t.Errorf("hits = %v, misses = %v; want 1 and 2", stats.CacheHits, stats.CacheMisses)
+	}
+}
+
+func TestCacheSkipsFailures(t *testing.T) {
+	var status, requests atomic.Int32
+	status.Store(http.StatusInternalServerError)
+	srv := newServer(t, &status, &requests)
+
+	c := httpclient.New(httpclient.Cache(time.Minute, 10))
+
+	for range 2 {
+		if _, err := get(t, c, srv.URL); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := requests.Load(); got != 2 {
+		t.Errorf("requests = %v, want 2: 5xx responses shouldn't be cached", got)
+	}
+}
@@ -0,0 +1,61 @@
+package httpclient
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Статистика клиента с момента создания
+type Stats struct {
+	// все вызовы Do, включая ответы из кэша и отклоненные запросы
+	Requests int64
+	// сетевые ошибки и ответы 5xx/429
+	Failures           int64
+	CacheHits          int64
+	CacheMisses        int64
+	CircuitRejections  int64
+	BulkheadRejections int64
+	// суммарное время запросов, дошедших до удаленного сервиса
+	TotalLatency time.Duration `swaggertype:"integer"`
+	MaxLatency   time.Duration `swaggertype:"integer"`
+
+	// состояние предохранителя: closed, open, half-open
+	State        string
+	InFlight     int
+	CacheEntries int
+}
+
+type metrics struct {
+	requests           atomic.Int64
+	failures           atomic.Int64
+	cacheHits          atomic.Int64
+	cacheMisses        atomic.Int64
+	circuitRejections  atomic.Int64
+	bulkheadRejections atomic.Int64
+	totalLatency       atomic.Int64
+	maxLatency         atomic.Int64
+}
+
+func (m *metrics) observe(latency time.Duration) {
+	m.totalLatency.Add(int64(latency))
+
+	for {
+		current := m.maxLatency.Load()
+		if int64(latency) <= current || m.maxLatency.CompareAndSwap(current, int64(latency)) {
+			return
+		}
+	}
+}
+
+func (m *metrics) snapshot() Stats {
+	return Stats{
+		Requests:           m.requests.Load(),
+		Failures:           m.failures.Load(),
+		CacheHits:          m.cacheHits.Load(),
+		CacheMisses:        m.cacheMisses.Load(),
+		CircuitRejections:  m.circuitRejections.Load(),
+		BulkheadRejections: m.bulkheadRejections.Load(),
+		TotalLatency:       time.Duration(m.totalLatency.Load()),
+		MaxLatency:         time.Duration(m.maxLatency.Load()),
+	}
+}
@@ -0,0 +1,67 @@
+package httpclient
+
+import (
+	"net/http"
+	"time"
+)
+
+type Option func(*Client)
+
+// Подменяет http-клиент, например, на клиент httptest.Server
+func HTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		c.client = client
+	}
+}
+
+// Таймаут одного запроса, включая чтение тела. 0 - без таймаута
+func Timeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// Размыкает цепь после threshold отказов подряд на openTimeout. threshold = 0 отключает предохранитель
+func CircuitBreaker(threshold int, openTimeout time.Duration) Option {
+	return func(c *Client) {
+		c.breaker = newBreaker(threshold, openTimeout, c.clock)
+	}
+}
+
+// Ограничивает количество одновременных запросов; остальные ждут свободного места не дольше queueTimeout
+func Bulkhead(maxConcurrent int, queueTimeout time.Duration) Option {
+	return func(c *Client) {
+		if maxConcurrent <= 0 {
+			maxConcurrent = defaultMaxConcurrent
+		}
+		c.bulkhead = make(chan struct{}, maxConcurrent)
+		c.queueTimeout = queueTimeout
+	}
+}
+
+// Кэширует ответы на GET-запросы на ttl. ttl = 0 отключает кэширование
+func Cache(ttl time.Duration, size int) Option {
+	return func(c *Client) {
+		if ttl <= 0 {
+			c.cache = nil
+			return
+		}
+		if size <= 0 {
+			size = defaultCacheSize
+		}
+		c.cache = newCache(ttl, size, c.clock)
+	}
+}
+
+func MaxBodySize(size int64) Option {
+	return func(c *Client) {
+		c.maxBodySize = size
+	}
+}
+
+// Подменяет источник времени для предохранителя, кэша и замеров задержки
+func Clock(now func() time.Time) Option {
+	return func(c *Client) {
+		c.now = now
+	}
+}